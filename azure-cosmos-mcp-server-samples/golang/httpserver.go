@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/abhirockzz/mcp_cosmosdb_go/tools"
+
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// serveHTTP starts the MCP streamable HTTP/SSE server on addr and blocks
+// until ctx is cancelled (e.g. on SIGTERM), at which point it shuts down
+// gracefully. authToken, when non-empty, is required as a bearer token on
+// every request; healthCheckAccount, when non-empty, is pinged on /healthz
+// to verify Cosmos DB connectivity rather than just process liveness.
+func serveHTTP(ctx context.Context, s *server.MCPServer, addr, authToken string, clientRetriever tools.CosmosDBClientRetriever, healthCheckAccount string) error {
+	streamableServer := server.NewStreamableHTTPServer(s)
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", withCORS(withBearerAuth(authToken, streamableServer)))
+	mux.HandleFunc("/healthz", healthzHandler(clientRetriever, healthCheckAccount))
+
+	httpServer := &http.Server{
+		Addr:    addr,
+		Handler: mux,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		fmt.Printf("listening for MCP streamable HTTP/SSE requests on %s\n", addr)
+		if err := httpServer.ListenAndServe(); err != nil && err != http.ErrServerClosed {
+			errCh <- err
+		}
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		return httpServer.Shutdown(shutdownCtx)
+	}
+}
+
+// withBearerAuth rejects requests missing the configured bearer token. When
+// token is empty, auth is disabled and every request is allowed through.
+func withBearerAuth(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authHeader := r.Header.Get("Authorization")
+		if authHeader != "Bearer "+token {
+			http.Error(w, "missing or invalid bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withCORS allows browser-based MCP clients to talk to the server from a
+// different origin, which stdio-spawned servers never had to worry about.
+func withCORS(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Access-Control-Allow-Origin", "*")
+		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, OPTIONS")
+		w.Header().Set("Access-Control-Allow-Headers", strings.Join([]string{"Content-Type", "Authorization", "Mcp-Session-Id"}, ", "))
+
+		if r.Method == http.MethodOptions {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// healthzHandler reports process liveness, and, when account is configured,
+// also verifies Cosmos DB connectivity by listing databases for that account.
+func healthzHandler(clientRetriever tools.CosmosDBClientRetriever, account string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if account == "" {
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintln(w, "ok")
+			return
+		}
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			http.Error(w, fmt.Sprintf("cosmos client error: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), 5*time.Second)
+		defer cancel()
+
+		queryPager := client.NewQueryDatabasesPager("select value count(1) from dbs", nil)
+		if _, err := queryPager.NextPage(ctx); err != nil {
+			http.Error(w, fmt.Sprintf("cosmos ping failed: %v", err), http.StatusServiceUnavailable)
+			return
+		}
+
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintln(w, "ok")
+	}
+}
@@ -1,14 +1,67 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/abhirockzz/mcp_cosmosdb_go/tools"
+	"github.com/abhirockzz/mcp_cosmosdb_go/tools/controlplane"
 
 	"github.com/mark3labs/mcp-go/server"
 )
 
 func main() {
+	authMode := flag.String("auth-mode", "key", "Authentication mode to use when connecting to Cosmos DB: 'key' (account key from COSMOSDB_ACCOUNT_KEY, default), 'aad' (Microsoft Entra ID via azidentity.NewDefaultAzureCredential), 'connection-string' (full connection string from COSMOSDB_CONNECTION_STRING), or 'key-vault' (account key fetched from an Azure Key Vault secret)")
+	keyVaultURI := flag.String("key-vault-uri", "", "Azure Key Vault URI to fetch the Cosmos DB account key from when --auth-mode=key-vault. Falls back to COSMOSDB_KEYVAULT_URI")
+	keyVaultSecretName := flag.String("key-vault-secret-name", "", "Name of the Key Vault secret holding the Cosmos DB account key when --auth-mode=key-vault. Falls back to COSMOSDB_KEYVAULT_SECRET_NAME")
+	transport := flag.String("transport", "stdio", "MCP transport to serve: 'stdio' (default, one server per client process) or 'http' (streamable HTTP/SSE server shared across clients)")
+	httpAddr := flag.String("http-addr", ":8080", "Address to listen on when --transport=http")
+	authToken := flag.String("http-auth-token", "", "Bearer token required on every request when --transport=http. Leave empty to disable auth (e.g. behind a trusted reverse proxy)")
+	healthAccount := flag.String("health-account", "", "Cosmos DB account to ping from /healthz when --transport=http. Leave empty to have /healthz only report process liveness")
+	flag.Parse()
+
+	shutdownTracing, tracingEnabled, err := tools.InitOTelTracing(context.Background())
+	if err != nil {
+		fmt.Printf("error initializing OpenTelemetry tracing: %v\n", err)
+		os.Exit(1)
+	}
+	if tracingEnabled {
+		defer shutdownTracing(context.Background())
+	}
+
+	var credential tools.Credential
+
+	switch *authMode {
+	case "aad":
+		credential = tools.AADCredential{}
+	case "key":
+		credential = tools.KeyCredential{}
+	case "connection-string":
+		credential = tools.ConnectionStringCredential{}
+	case "key-vault":
+		credential = tools.KeyVaultKeyCredential{VaultURI: *keyVaultURI, SecretName: *keyVaultSecretName}
+	default:
+		fmt.Printf("unsupported auth-mode %q, expected 'key', 'aad', 'connection-string' or 'key-vault'\n", *authMode)
+		os.Exit(1)
+	}
+
+	// PooledClientRetriever keeps one *azcosmos.Client per account name, so
+	// repeated tool calls reuse the same client instead of paying its TLS
+	// handshake on every request. TracingClientRetriever wraps it so client
+	// acquisition - including pool misses that build a new *azcosmos.Client -
+	// gets its own span. That span is a root span in a trace of its own, not
+	// a child of the tool call's span, since Get takes no context.
+	var clientRetriever tools.CosmosDBClientRetriever = tools.TracingClientRetriever{
+		Inner: &tools.PooledClientRetriever{
+			Credential:      credential,
+			TracingProvider: tools.NewOTelTracingProvider(),
+		},
+	}
+
 	// Create MCP server
 	s := server.NewMCPServer(
 		"Azure Cosmos DB MCP server 🚀",
@@ -16,18 +69,52 @@ func main() {
 		server.WithLogging(),
 	)
 
-	s.AddTool(tools.ListDatabases(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.ListContainers(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.ReadContainerMetadata(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.CreateContainer(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.AddItemToContainer(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.ReadItem(tools.CosmosDBServiceClientRetriever{}))
-	s.AddTool(tools.ExecuteQuery(tools.CosmosDBServiceClientRetriever{}))
+	schemaRegistry := tools.NewSchemaRegistry()
+
+	s.AddTool(tools.ListDatabases(clientRetriever))
+	s.AddTool(tools.ListContainers(clientRetriever))
+	s.AddTool(tools.ReadContainerMetadata(clientRetriever))
+	s.AddTool(tools.CreateContainer(clientRetriever))
+	s.AddTool(tools.AddItemToContainer(clientRetriever, schemaRegistry))
+	s.AddTool(tools.ReplaceItem(clientRetriever, schemaRegistry))
+	s.AddTool(tools.UpsertItem(clientRetriever, schemaRegistry))
+	s.AddTool(tools.DeleteItem(clientRetriever))
+	s.AddTool(tools.RegisterItemSchema(schemaRegistry))
+	s.AddTool(tools.ReadItem(clientRetriever))
+	s.AddTool(tools.ExecuteQuery(clientRetriever))
+	s.AddTool(tools.ExecuteTransactionalBatch(clientRetriever))
+	s.AddTool(tools.BulkUpsertItems(clientRetriever))
+	s.AddTool(tools.PatchItem(clientRetriever))
+	s.AddTool(tools.SubscribeChangeFeed(clientRetriever))
+	s.AddTool(tools.ResetChangeFeedLease(clientRetriever))
+
+	// Management-plane (ARM) tools for provisioning accounts/databases/containers,
+	// separate from the data-plane clientRetriever above.
+	controlPlaneRetriever := controlplane.ControlPlaneClientRetriever{}
+	s.AddTool(controlplane.CreateCosmosAccount(controlPlaneRetriever))
+	s.AddTool(controlplane.ListAccountsInSubscription(controlPlaneRetriever))
+	s.AddTool(controlplane.ListAccountKeys(controlPlaneRetriever))
+	s.AddTool(controlplane.CreateSQLDatabaseARM(controlPlaneRetriever))
+	s.AddTool(controlplane.DeleteSQLDatabaseARM(controlPlaneRetriever))
+	s.AddTool(controlplane.CreateSQLContainerARM(controlPlaneRetriever))
+	s.AddTool(controlplane.UpdateThroughput(controlPlaneRetriever))
 
 	//fmt.Println("starting mcp go server for cosmosdb")
 
-	// Start the stdio server
-	if err := server.ServeStdio(s); err != nil {
-		fmt.Printf("Server error: %v\n", err)
+	switch *transport {
+	case "http":
+		ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+		defer stop()
+
+		if err := serveHTTP(ctx, s, *httpAddr, *authToken, clientRetriever, *healthAccount); err != nil {
+			fmt.Printf("Server error: %v\n", err)
+		}
+	case "stdio":
+		if err := server.ServeStdio(s); err != nil {
+			fmt.Printf("Server error: %v\n", err)
+		}
+	default:
+		fmt.Printf("unsupported transport %q, expected 'stdio' or 'http'\n", *transport)
+		os.Exit(1)
 	}
 }
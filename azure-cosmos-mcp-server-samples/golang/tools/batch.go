@@ -0,0 +1,417 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const EXECUTE_TRANSACTIONAL_BATCH_TOOL_NAME = "execute_transactional_batch"
+const BULK_UPSERT_ITEMS_TOOL_NAME = "bulk_upsert_items"
+
+// bulkUpsertConcurrency bounds how many partition-key batches are sent to
+// Cosmos DB at the same time by BulkUpsertItems.
+const bulkUpsertConcurrency = 8
+
+// maxTransactionalBatchOperations is Cosmos DB's limit on the number of
+// operations allowed in a single transactional batch request. BulkUpsertItems
+// splits each partition key's items into sub-batches of at most this many
+// operations so that a partition key with more items than the limit degrades
+// to multiple batches instead of failing outright.
+const maxTransactionalBatchOperations = 100
+
+// chunkItems splits items into consecutive slices of at most size elements.
+func chunkItems(items []json.RawMessage, size int) [][]json.RawMessage {
+	var chunks [][]json.RawMessage
+	for len(items) > 0 {
+		end := size
+		if end > len(items) {
+			end = len(items)
+		}
+		chunks = append(chunks, items[:end])
+		items = items[end:]
+	}
+	return chunks
+}
+
+// BatchOperation describes a single operation within a transactional batch
+// request. All operations in a batch share the same partition key.
+type BatchOperation struct {
+	Operation       string          `json:"operation"`
+	ID              string          `json:"id,omitempty"`
+	Item            json.RawMessage `json:"item,omitempty"`
+	PatchOperations json.RawMessage `json:"patchOperations,omitempty"`
+}
+
+// BatchOperationResult reports the outcome of one operation within a
+// transactional batch.
+type BatchOperationResult struct {
+	StatusCode    int32   `json:"statusCode"`
+	RequestCharge float32 `json:"requestCharge"`
+}
+
+type ExecuteTransactionalBatchResponse struct {
+	Success          bool                   `json:"success"`
+	RequestCharge    float32                `json:"requestCharge"`
+	OperationResults []BatchOperationResult `json:"operationResults"`
+}
+
+func ExecuteTransactionalBatch(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return executeTransactionalBatch(), func(ctx context.Context, request mcp.CallToolRequest) (toolResult *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		partitionKeyValue, ok := request.Params.Arguments["partitionKey"].(string)
+		if !ok || partitionKeyValue == "" {
+			return nil, errors.New("value for partition key missing")
+		}
+		operationsJSON, ok := request.Params.Arguments["operations"].(string)
+		if !ok || operationsJSON == "" {
+			return nil, errors.New("operations JSON array missing")
+		}
+
+		var operations []BatchOperation
+		if err := json.Unmarshal([]byte(operationsJSON), &operations); err != nil {
+			return nil, fmt.Errorf("error unmarshalling operations JSON: %v", err)
+		}
+		if len(operations) == 0 {
+			return nil, errors.New("operations array must contain at least one operation")
+		}
+		for _, op := range operations {
+			switch op.Operation {
+			case "create", "upsert":
+			case "replace", "delete", "patch":
+				if op.ID == "" {
+					return nil, fmt.Errorf("%s operation requires an id", op.Operation)
+				}
+			default:
+				return nil, fmt.Errorf("unsupported batch operation %q", op.Operation)
+			}
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, EXECUTE_TRANSACTIONAL_BATCH_TOOL_NAME, account, database, container, partitionKeyValue, "")
+		defer func() { endToolSpan(ctx, span, EXECUTE_TRANSACTIONAL_BATCH_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+		batch := containerClient.NewTransactionalBatch(partitionKey)
+
+		for _, op := range operations {
+			switch op.Operation {
+			case "create":
+				batch.CreateItem([]byte(op.Item), nil)
+			case "upsert":
+				batch.UpsertItem([]byte(op.Item), nil)
+			case "replace":
+				if op.ID == "" {
+					return nil, errors.New("replace operation requires an id")
+				}
+				batch.ReplaceItem(op.ID, []byte(op.Item), nil)
+			case "delete":
+				if op.ID == "" {
+					return nil, errors.New("delete operation requires an id")
+				}
+				batch.DeleteItem(op.ID, nil)
+			case "patch":
+				if op.ID == "" {
+					return nil, errors.New("patch operation requires an id")
+				}
+				patchOps, err := parsePatchOperations(op.PatchOperations)
+				if err != nil {
+					return nil, err
+				}
+				batch.PatchItem(op.ID, patchOps, nil)
+			default:
+				return nil, fmt.Errorf("unsupported batch operation %q", op.Operation)
+			}
+		}
+
+		var batchResponse azcosmos.TransactionalBatchResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var batchErr error
+			batchResponse, batchErr = containerClient.ExecuteTransactionalBatch(ctx, batch, nil)
+			return batchErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error executing transactional batch", attempts+1, retryErr)
+		}
+		requestCharge = batchResponse.RequestCharge
+
+		response := ExecuteTransactionalBatchResponse{
+			Success:       batchResponse.Success,
+			RequestCharge: batchResponse.RequestCharge,
+		}
+		for _, result := range batchResponse.OperationResults {
+			response.OperationResults = append(response.OperationResults, BatchOperationResult{
+				StatusCode:    int32(result.StatusCode),
+				RequestCharge: result.RequestCharge,
+			})
+		}
+
+		jsonResult, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling result to JSON: %v", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+}
+
+func executeTransactionalBatch() mcp.Tool {
+	return mcp.NewTool(EXECUTE_TRANSACTIONAL_BATCH_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container to run the batch against"),
+		),
+		mcp.WithString("partitionKey",
+			mcp.Required(),
+			mcp.Description("Partition key value shared by every operation in the batch"),
+		),
+		mcp.WithString("operations",
+			mcp.Required(),
+			mcp.Description("JSON array of operations to run atomically, e.g. [{\"operation\":\"upsert\",\"item\":{...}},{\"operation\":\"delete\",\"id\":\"...\"}]. Supported operation values: create, upsert, replace, delete, patch."),
+		),
+		mcp.WithDescription("Execute multiple create/upsert/replace/delete/patch operations against a single partition key as one atomic transaction, returning per-operation status codes and the total RU charge."),
+	)
+}
+
+// patchOperationSpec is the wire shape of a single entry in a patch
+// operations JSON array, e.g. {"op":"set","path":"/status","value":"done"}.
+type patchOperationSpec struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value,omitempty"`
+}
+
+// parsePatchOperations unmarshals a JSON array of patch operation specs into
+// an azcosmos.PatchOperations, supporting the set/add/remove/replace/incr
+// verbs exposed by the Cosmos DB partial-update API.
+func parsePatchOperations(raw json.RawMessage) (azcosmos.PatchOperations, error) {
+	var specs []patchOperationSpec
+	if err := json.Unmarshal(raw, &specs); err != nil {
+		return azcosmos.PatchOperations{}, fmt.Errorf("error unmarshalling patch operations JSON: %v", err)
+	}
+
+	patchOperations := azcosmos.PatchOperations{}
+	for _, spec := range specs {
+		if spec.Path == "" {
+			return azcosmos.PatchOperations{}, errors.New("patch operation missing path")
+		}
+		switch spec.Op {
+		case "set":
+			patchOperations.AppendSet(spec.Path, spec.Value)
+		case "add":
+			patchOperations.AppendAdd(spec.Path, spec.Value)
+		case "replace":
+			patchOperations.AppendReplace(spec.Path, spec.Value)
+		case "remove":
+			patchOperations.AppendRemove(spec.Path)
+		case "incr":
+			increment, ok := spec.Value.(float64)
+			if !ok {
+				return azcosmos.PatchOperations{}, fmt.Errorf("incr operation at path %q requires a numeric value", spec.Path)
+			}
+			patchOperations.AppendIncrement(spec.Path, int64(increment))
+		default:
+			return azcosmos.PatchOperations{}, fmt.Errorf("unsupported patch operation %q", spec.Op)
+		}
+	}
+
+	return patchOperations, nil
+}
+
+// BulkUpsertItem is a single item to be upserted by BulkUpsertItems, paired
+// with the partition key it belongs to.
+type BulkUpsertItem struct {
+	PartitionKey string          `json:"partitionKey"`
+	Item         json.RawMessage `json:"item"`
+}
+
+type BulkUpsertItemsResponse struct {
+	ItemsUpserted int      `json:"itemsUpserted"`
+	BatchesSent   int      `json:"batchesSent"`
+	Errors        []string `json:"errors,omitempty"`
+}
+
+func BulkUpsertItems(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return bulkUpsertItems(), func(ctx context.Context, request mcp.CallToolRequest) (toolResult *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		itemsJSON, ok := request.Params.Arguments["items"].(string)
+		if !ok || itemsJSON == "" {
+			return nil, errors.New("items JSON array missing")
+		}
+
+		var items []BulkUpsertItem
+		if err := json.Unmarshal([]byte(itemsJSON), &items); err != nil {
+			return nil, fmt.Errorf("error unmarshalling items JSON: %v", err)
+		}
+		if len(items) == 0 {
+			return nil, errors.New("items array must contain at least one item")
+		}
+
+		// Group items by partition key so each batch only touches one logical partition.
+		grouped := map[string][]json.RawMessage{}
+		for _, item := range items {
+			if item.PartitionKey == "" {
+				return nil, errors.New("every item must specify a partitionKey")
+			}
+			grouped[item.PartitionKey] = append(grouped[item.PartitionKey], item.Item)
+		}
+
+		ctx, span, start := startToolSpan(ctx, BULK_UPSERT_ITEMS_TOOL_NAME, account, database, container, "", "")
+		defer func() { endToolSpan(ctx, span, BULK_UPSERT_ITEMS_TOOL_NAME, start, 0, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		var (
+			mu            sync.Mutex
+			wg            sync.WaitGroup
+			semaphore     = make(chan struct{}, bulkUpsertConcurrency)
+			itemsUpserted int
+			batchesSent   int
+			upsertErrors  []string
+		)
+
+		for partitionKeyValue, groupItems := range grouped {
+			wg.Add(1)
+			semaphore <- struct{}{}
+
+			go func(partitionKeyValue string, groupItems []json.RawMessage) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+
+				partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+
+				for _, chunk := range chunkItems(groupItems, maxTransactionalBatchOperations) {
+					batch := containerClient.NewTransactionalBatch(partitionKey)
+					for _, item := range chunk {
+						batch.UpsertItem([]byte(item), nil)
+					}
+
+					var batchResponse azcosmos.TransactionalBatchResponse
+					_, _, err := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+						var batchErr error
+						batchResponse, batchErr = containerClient.ExecuteTransactionalBatch(ctx, batch, nil)
+						return batchErr
+					})
+
+					mu.Lock()
+					batchesSent++
+					if err != nil {
+						upsertErrors = append(upsertErrors, fmt.Sprintf("partition key %q: %v", partitionKeyValue, err))
+						mu.Unlock()
+						continue
+					}
+					if !batchResponse.Success {
+						upsertErrors = append(upsertErrors, fmt.Sprintf("partition key %q: batch did not fully succeed", partitionKeyValue))
+						mu.Unlock()
+						continue
+					}
+					itemsUpserted += len(chunk)
+					mu.Unlock()
+				}
+			}(partitionKeyValue, groupItems)
+		}
+
+		wg.Wait()
+
+		jsonResult, err := json.Marshal(BulkUpsertItemsResponse{
+			ItemsUpserted: itemsUpserted,
+			BatchesSent:   batchesSent,
+			Errors:        upsertErrors,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling result to JSON: %v", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+}
+
+func bulkUpsertItems() mcp.Tool {
+	return mcp.NewTool(BULK_UPSERT_ITEMS_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container to upsert the items into"),
+		),
+		mcp.WithString("items",
+			mcp.Required(),
+			mcp.Description(fmt.Sprintf("JSON array of items to upsert, each shaped as {\"partitionKey\": \"...\", \"item\": {...}}. Items are grouped by partitionKey and sent as transactional batches fanned out concurrently, split into sub-batches of at most %d items per partition key.", maxTransactionalBatchOperations)),
+		),
+		mcp.WithDescription("Bulk upsert many items into a container efficiently by grouping them by partition key and sending concurrent transactional batches, instead of one round-trip per item. Partition keys with more items than a transactional batch allows are sent as multiple sub-batches."),
+	)
+}
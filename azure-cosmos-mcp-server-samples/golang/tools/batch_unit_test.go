@@ -0,0 +1,90 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChunkItemsUnit(t *testing.T) {
+	items := make([]json.RawMessage, 250)
+	for i := range items {
+		items[i] = json.RawMessage("{}")
+	}
+
+	chunks := chunkItems(items, maxTransactionalBatchOperations)
+	require.Len(t, chunks, 3)
+	assert.Len(t, chunks[0], 100)
+	assert.Len(t, chunks[1], 100)
+	assert.Len(t, chunks[2], 50)
+
+	assert.Empty(t, chunkItems(nil, maxTransactionalBatchOperations))
+
+	single := chunkItems(items[:5], maxTransactionalBatchOperations)
+	require.Len(t, single, 1)
+	assert.Len(t, single[0], 5)
+}
+
+func TestExecuteTransactionalBatchUnit(t *testing.T) {
+	tool, handler := ExecuteTransactionalBatch(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, EXECUTE_TRANSACTIONAL_BATCH_TOOL_NAME,
+		[]string{"account", "database", "container", "partitionKey", "operations"},
+		[]string{"account", "database", "container", "partitionKey", "operations"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk", "operations": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling operations JSON")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk", "operations": "[]",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "operations array must contain at least one operation")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk",
+		"operations": `[{"operation":"bogus"}]`,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unsupported batch operation "bogus"`)
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk",
+		"operations": `[{"operation":"delete"}]`,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "delete operation requires an id")
+}
+
+func TestBulkUpsertItemsUnit(t *testing.T) {
+	tool, handler := BulkUpsertItems(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, BULK_UPSERT_ITEMS_TOOL_NAME,
+		[]string{"account", "database", "container", "items"},
+		[]string{"account", "database", "container", "items"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "items": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling items JSON")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "items": "[]",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "items array must contain at least one item")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c",
+		"items": `[{"item":{"id":"1"}}]`,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "every item must specify a partitionKey")
+}
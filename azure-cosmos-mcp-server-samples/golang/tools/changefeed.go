@@ -0,0 +1,339 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const SUBSCRIBE_CHANGE_FEED_TOOL_NAME = "subscribe_change_feed"
+const RESET_CHANGE_FEED_LEASE_TOOL_NAME = "reset_change_feed_lease"
+
+// changeFeedLease is the document SubscribeChangeFeed checkpoints into the
+// lease container so repeated calls resume from where the previous one left
+// off instead of replaying the whole feed.
+type changeFeedLease struct {
+	ID                string `json:"id"`
+	ContinuationToken string `json:"continuationToken"`
+}
+
+type ChangeFeedResponse struct {
+	Changes         []json.RawMessage `json:"changes"`
+	NewContinuation string            `json:"newContinuation,omitempty"`
+	LeaseDocumentID string            `json:"leaseDocumentId"`
+}
+
+// changeFeedLeaseID identifies the lease document tracking change feed
+// progress for a given database/container pair.
+func changeFeedLeaseID(database, container string) string {
+	return fmt.Sprintf("changefeed-lease-%s-%s", database, container)
+}
+
+// ensureLeaseContainer returns a client for leaseContainer, creating it with
+// an "/id" partition key if it doesn't already exist.
+func ensureLeaseContainer(ctx context.Context, databaseClient *azcosmos.DatabaseClient, leaseContainer string) (*azcosmos.ContainerClient, error) {
+	containerClient, err := databaseClient.NewContainer(leaseContainer)
+	if err != nil {
+		return nil, fmt.Errorf("error creating lease container client: %v", err)
+	}
+
+	if _, err := containerClient.Read(ctx, nil); err != nil {
+		var responseErr *azcore.ResponseError
+		if !errors.As(err, &responseErr) || responseErr.StatusCode != http.StatusNotFound {
+			return nil, err
+		}
+
+		properties := azcosmos.ContainerProperties{
+			ID: leaseContainer,
+			PartitionKeyDefinition: azcosmos.PartitionKeyDefinition{
+				Paths: []string{"/id"},
+			},
+		}
+		if _, err := databaseClient.CreateContainer(ctx, properties, nil); err != nil {
+			return nil, fmt.Errorf("error creating lease container: %v", err)
+		}
+	}
+
+	return containerClient, nil
+}
+
+// fetchChangeFeedLease returns the checkpointed continuation token for
+// leaseID, or "" if no lease document exists yet.
+func fetchChangeFeedLease(ctx context.Context, leaseContainerClient *azcosmos.ContainerClient, leaseID string) (string, error) {
+	partitionKey := azcosmos.NewPartitionKeyString(leaseID)
+
+	itemResponse, err := leaseContainerClient.ReadItem(ctx, partitionKey, leaseID, nil)
+	if err != nil {
+		var responseErr *azcore.ResponseError
+		if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+			return "", nil
+		}
+		return "", err
+	}
+
+	var lease changeFeedLease
+	if err := json.Unmarshal(itemResponse.Value, &lease); err != nil {
+		return "", fmt.Errorf("error unmarshalling lease document: %v", err)
+	}
+
+	return lease.ContinuationToken, nil
+}
+
+// saveChangeFeedLease upserts the checkpointed continuation token for
+// leaseID.
+func saveChangeFeedLease(ctx context.Context, leaseContainerClient *azcosmos.ContainerClient, leaseID, continuationToken string) error {
+	lease := changeFeedLease{ID: leaseID, ContinuationToken: continuationToken}
+
+	leaseJSON, err := json.Marshal(lease)
+	if err != nil {
+		return fmt.Errorf("error marshalling lease document: %v", err)
+	}
+
+	partitionKey := azcosmos.NewPartitionKeyString(leaseID)
+	_, err = leaseContainerClient.UpsertItem(ctx, partitionKey, leaseJSON, nil)
+	return err
+}
+
+// SubscribeChangeFeed reads the next batch of changes from a container's
+// change feed, checkpointing the continuation token into leaseContainer so
+// subsequent calls resume from where this one left off. This lets an MCP
+// client build incremental sync agents purely through repeated tool calls
+// without maintaining its own state.
+func SubscribeChangeFeed(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return subscribeChangeFeed(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		leaseContainer, ok := request.Params.Arguments["leaseContainer"].(string)
+		if !ok || leaseContainer == "" {
+			return nil, errors.New("lease container name missing")
+		}
+
+		startFrom, ok := request.Params.Arguments["startFrom"].(string)
+		if !ok || startFrom == "" {
+			startFrom = "Beginning"
+		}
+		timestamp, _ := request.Params.Arguments["timestamp"].(string)
+		if startFrom == "Timestamp" && timestamp == "" {
+			return nil, errors.New("timestamp is required when startFrom is \"Timestamp\"")
+		}
+
+		var maxItems int
+		if raw, ok := request.Params.Arguments["maxItems"].(float64); ok {
+			maxItems = int(raw)
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, SUBSCRIBE_CHANGE_FEED_TOOL_NAME, account, database, container, "", "")
+		defer func() { endToolSpan(ctx, span, SUBSCRIBE_CHANGE_FEED_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		leaseContainerClient, err := ensureLeaseContainer(ctx, databaseClient, leaseContainer)
+		if err != nil {
+			return nil, fmt.Errorf("error preparing lease container: %v", err)
+		}
+
+		leaseID := changeFeedLeaseID(database, container)
+
+		continuationToken, err := fetchChangeFeedLease(ctx, leaseContainerClient, leaseID)
+		if err != nil {
+			return nil, fmt.Errorf("error reading change feed lease: %v", err)
+		}
+
+		var changeFeedStartFrom azcosmos.ChangeFeedStartFrom
+		switch {
+		case continuationToken != "":
+			changeFeedStartFrom = azcosmos.ChangeFeedStartFromContinuation(continuationToken)
+		case startFrom == "Now":
+			changeFeedStartFrom = azcosmos.ChangeFeedStartFromNow()
+		case startFrom == "Timestamp":
+			parsedTimestamp, err := time.Parse(time.RFC3339, timestamp)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing timestamp: %v", err)
+			}
+			changeFeedStartFrom = azcosmos.ChangeFeedStartFromTime(parsedTimestamp)
+		case startFrom == "Beginning":
+			changeFeedStartFrom = azcosmos.ChangeFeedStartFromBeginning()
+		default:
+			return nil, fmt.Errorf("unsupported startFrom %q, expected 'Beginning', 'Now' or 'Timestamp'", startFrom)
+		}
+
+		changeFeedOptions := azcosmos.ChangeFeedOptions{StartFrom: changeFeedStartFrom}
+		if maxItems > 0 {
+			changeFeedOptions.MaxItemCount = int32(maxItems)
+		}
+
+		changeFeedPager := containerClient.NewChangeFeedPager(changeFeedOptions)
+
+		response := ChangeFeedResponse{Changes: []json.RawMessage{}, LeaseDocumentID: leaseID}
+
+		if changeFeedPager.More() {
+			changeFeedResponse, err := changeFeedPager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error reading change feed page: %v", err)
+			}
+			requestCharge = changeFeedResponse.RequestCharge
+
+			for _, change := range changeFeedResponse.Items {
+				response.Changes = append(response.Changes, json.RawMessage(change))
+			}
+
+			if changeFeedResponse.ContinuationToken != nil {
+				response.NewContinuation = *changeFeedResponse.ContinuationToken
+				if err := saveChangeFeedLease(ctx, leaseContainerClient, leaseID, *changeFeedResponse.ContinuationToken); err != nil {
+					return nil, fmt.Errorf("error checkpointing change feed lease: %v", err)
+				}
+			}
+		}
+
+		jsonResult, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling result to JSON: %v", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+}
+
+func subscribeChangeFeed() mcp.Tool {
+	return mcp.NewTool(SUBSCRIBE_CHANGE_FEED_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container to read changes from"),
+		),
+		mcp.WithString("leaseContainer",
+			mcp.Required(),
+			mcp.Description("Name of the container used to checkpoint change feed progress. Created automatically with an /id partition key if it doesn't exist."),
+		),
+		mcp.WithString("startFrom",
+			mcp.Description("Where to start reading the change feed when no checkpoint exists yet: 'Beginning' (default), 'Now', or 'Timestamp'"),
+		),
+		mcp.WithString("timestamp",
+			mcp.Description("RFC3339 timestamp to start reading from. Required when startFrom is 'Timestamp', ignored otherwise."),
+		),
+		mcp.WithNumber("maxItems",
+			mcp.Description("Maximum number of changes to return in this batch (optional, the service may return fewer)"),
+		),
+		mcp.WithDescription("Read the next batch of changes from a container's change feed, checkpointing progress into leaseContainer so the next call picks up where this one left off. Use reset_change_feed_lease to restart from startFrom."),
+	)
+}
+
+// ResetChangeFeedLease deletes a container's change feed lease document so
+// the next SubscribeChangeFeed call restarts from its startFrom argument
+// instead of resuming from the last checkpoint.
+func ResetChangeFeedLease(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return resetChangeFeedLease(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		leaseContainer, ok := request.Params.Arguments["leaseContainer"].(string)
+		if !ok || leaseContainer == "" {
+			return nil, errors.New("lease container name missing")
+		}
+
+		ctx, span, start := startToolSpan(ctx, RESET_CHANGE_FEED_LEASE_TOOL_NAME, account, database, container, "", "")
+		defer func() { endToolSpan(ctx, span, RESET_CHANGE_FEED_LEASE_TOOL_NAME, start, 0, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		leaseContainerClient, err := databaseClient.NewContainer(leaseContainer)
+		if err != nil {
+			return nil, fmt.Errorf("error creating lease container client: %v", err)
+		}
+
+		leaseID := changeFeedLeaseID(database, container)
+		partitionKey := azcosmos.NewPartitionKeyString(leaseID)
+
+		if _, err := leaseContainerClient.DeleteItem(ctx, partitionKey, leaseID, nil); err != nil {
+			var responseErr *azcore.ResponseError
+			if errors.As(err, &responseErr) && responseErr.StatusCode == http.StatusNotFound {
+				return mcp.NewToolResultText(fmt.Sprintf("No change feed lease found for container '%s' in database '%s'", container, database)), nil
+			}
+			return nil, fmt.Errorf("error deleting lease document: %v", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Change feed lease reset for container '%s' in database '%s'", container, database)), nil
+	}
+}
+
+func resetChangeFeedLease() mcp.Tool {
+	return mcp.NewTool(RESET_CHANGE_FEED_LEASE_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container whose change feed lease should be reset"),
+		),
+		mcp.WithString("leaseContainer",
+			mcp.Required(),
+			mcp.Description("Name of the container holding the change feed lease document"),
+		),
+		mcp.WithDescription("Delete a container's change feed lease so the next subscribe_change_feed call restarts from its startFrom argument instead of resuming from the last checkpoint."),
+	)
+}
@@ -1,10 +1,10 @@
 package tools
 
 import (
-	"fmt"
-	"os"
+	"sync"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 )
 
@@ -24,39 +24,38 @@ type CosmosDBClientRetriever interface {
 	Get(accountName string) (*azcosmos.Client, error)
 }
 
-type CosmosDBServiceClientRetriever struct {
-	//accountName string
+// PooledClientRetriever resolves a *azcosmos.Client per request via a
+// pluggable Credential strategy (KeyCredential, AADCredential,
+// ConnectionStringCredential, ...), keeping one client per account name in a
+// sync.Map instead of building a fresh client - and paying its TLS handshake
+// - on every tool invocation. Use a *PooledClientRetriever (not a value), so
+// every Get call shares the same pool.
+type PooledClientRetriever struct {
+	Credential Credential
+
+	// TracingProvider, when set, is passed through to every client the pool
+	// creates, so SDK operations (and the spans MCP tool handlers create
+	// around them) are exported via OpenTelemetry. Leave unset to disable
+	// tracing.
+	TracingProvider tracing.Provider
+
+	clients sync.Map // account name -> *azcosmos.Client
 }
 
-func (retriever CosmosDBServiceClientRetriever) Get(accountName string) (*azcosmos.Client, error) {
-	endpoint := fmt.Sprintf("https://%s.documents.azure.com:443/", accountName)
-
-	accountKey := os.Getenv("COSMOSDB_ACCOUNT_KEY")
-	// if only account name is provided, use managed identity
-	if accountKey == "" {
-		cred, err := azidentity.NewDefaultAzureCredential(nil)
-		if err != nil {
-			return nil, fmt.Errorf("error creating credential: %v", err)
-		}
-
-		client, err := azcosmos.NewClient(endpoint, cred, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error creating Cosmos client: %v", err)
-		}
-
-		return client, nil
-	} else {
-		// if both account name and key are provided, use the key
-		cred, err := azcosmos.NewKeyCredential(accountKey)
-		if err != nil {
-			return nil, fmt.Errorf("error creating key credential: %v", err)
-		}
-
-		client, err := azcosmos.NewClientWithKey(endpoint, cred, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error creating Cosmos client: %v", err)
-		}
-
-		return client, nil
+func (retriever *PooledClientRetriever) Get(accountName string) (*azcosmos.Client, error) {
+	if cached, ok := retriever.clients.Load(accountName); ok {
+		return cached.(*azcosmos.Client), nil
 	}
+
+	options := &azcosmos.ClientOptions{ClientOptions: azcore.ClientOptions{
+		TracingProvider: retriever.TracingProvider,
+	}}
+
+	client, err := retriever.Credential.NewClient(accountName, options)
+	if err != nil {
+		return nil, err
+	}
+
+	actual, _ := retriever.clients.LoadOrStore(accountName, client)
+	return actual.(*azcosmos.Client), nil
 }
@@ -6,7 +6,6 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
@@ -14,7 +13,7 @@ import (
 
 func ListContainers(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
 
-	return listContainers(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return listContainers(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
@@ -25,6 +24,9 @@ func ListContainers(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.T
 			return nil, errors.New("database name missing")
 		}
 
+		ctx, span, start := startToolSpan(ctx, LIST_CONTAINERS_TOOL_NAME, account, database, "", "", "")
+		defer func() { endToolSpan(ctx, span, LIST_CONTAINERS_TOOL_NAME, start, 0, 0, err) }()
+
 		client, err := clientRetriever.Get(account)
 
 		if err != nil {
@@ -45,12 +47,16 @@ func ListContainers(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.T
 
 		containerNames := []string{}
 
+		retryPolicy := RetryPolicyFromEnv()
 		for containerPager.More() {
-			containerResponse, err := containerPager.NextPage(context.Background())
-			if err != nil {
-				var responseErr *azcore.ResponseError
-				errors.As(err, &responseErr)
-				return nil, err
+			var containerResponse azcosmos.QueryContainersResponse
+			attempts, _, retryErr := retryOnThrottle(ctx, retryPolicy, func() error {
+				var pageErr error
+				containerResponse, pageErr = containerPager.NextPage(ctx)
+				return pageErr
+			})
+			if retryErr != nil {
+				return nil, wrapRetryExhausted("error listing containers", attempts+1, retryErr)
 			}
 
 			for _, container := range containerResponse.Containers {
@@ -98,7 +104,7 @@ func listContainers() mcp.Tool {
 }
 
 func ReadContainerMetadata(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
-	return readContainerMetadata(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return readContainerMetadata(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
@@ -113,6 +119,9 @@ func ReadContainerMetadata(clientRetriever CosmosDBClientRetriever) (mcp.Tool, s
 			return nil, errors.New("container name missing")
 		}
 
+		ctx, span, start := startToolSpan(ctx, READ_CONTAINER_METADATA_TOOL_NAME, account, database, container, "", "")
+		defer func() { endToolSpan(ctx, span, READ_CONTAINER_METADATA_TOOL_NAME, start, 0, 0, err) }()
+
 		client, err := clientRetriever.Get(account)
 
 		if err != nil {
@@ -135,11 +144,14 @@ func ReadContainerMetadata(clientRetriever CosmosDBClientRetriever) (mcp.Tool, s
 		// 	return nil, fmt.Errorf("error creating container client: %v", err)
 		// }
 
-		response, err := containerClient.Read(context.Background(), nil)
-		if err != nil {
-			var responseErr *azcore.ResponseError
-			errors.As(err, &responseErr)
-			return nil, err
+		var response azcosmos.ContainerResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var readErr error
+			response, readErr = containerClient.Read(ctx, nil)
+			return readErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error reading container metadata", attempts+1, retryErr)
 		}
 
 		metadata := map[string]interface{}{
@@ -179,7 +191,7 @@ func readContainerMetadata() mcp.Tool {
 }
 
 func CreateContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
-	return createContainer(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return createContainer(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
 			return nil, errors.New("cosmos db account name missing")
@@ -192,11 +204,59 @@ func CreateContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.
 		if !ok || container == "" {
 			return nil, errors.New("container name missing")
 		}
-		partitionKeyPath, ok := request.Params.Arguments["partitionKeyPath"].(string)
-		if !ok || partitionKeyPath == "" {
-			return nil, errors.New("partition key path missing")
+
+		partitionKeyPaths, err := partitionKeyPathsFromArguments(request.Params.Arguments)
+		if err != nil {
+			return nil, err
+		}
+
+		throughput, hasThroughput := request.Params.Arguments["throughput"].(float64)
+		maxThroughput, hasMaxThroughput := request.Params.Arguments["maxThroughput"].(float64)
+		if hasThroughput && hasMaxThroughput {
+			return nil, errors.New("throughput and maxThroughput are mutually exclusive")
+		}
+
+		properties := azcosmos.ContainerProperties{
+			ID: container,
+			PartitionKeyDefinition: azcosmos.PartitionKeyDefinition{
+				Paths: partitionKeyPaths,
+			},
+		}
+		if len(partitionKeyPaths) > 1 {
+			properties.PartitionKeyDefinition.Kind = azcosmos.PartitionKeyKindMultiHash
+		}
+
+		if raw, ok := request.Params.Arguments["defaultTtlSeconds"].(float64); ok {
+			ttl := int32(raw)
+			properties.DefaultTimeToLive = &ttl
 		}
-		throughput, hasThroughput := request.Params.Arguments["throughput"].(int)
+
+		if indexingPolicyJSON, ok := request.Params.Arguments["indexingPolicy"].(string); ok && indexingPolicyJSON != "" {
+			indexingPolicy, err := parseIndexingPolicy(indexingPolicyJSON)
+			if err != nil {
+				return nil, err
+			}
+			properties.IndexingPolicy = indexingPolicy
+		}
+
+		if uniqueKeyPolicyJSON, ok := request.Params.Arguments["uniqueKeyPolicy"].(string); ok && uniqueKeyPolicyJSON != "" {
+			uniqueKeyPolicy, err := parseUniqueKeyPolicy(uniqueKeyPolicyJSON)
+			if err != nil {
+				return nil, err
+			}
+			properties.UniqueKeyPolicy = uniqueKeyPolicy
+		}
+
+		if conflictResolutionPolicyJSON, ok := request.Params.Arguments["conflictResolutionPolicy"].(string); ok && conflictResolutionPolicyJSON != "" {
+			conflictResolutionPolicy, err := parseConflictResolutionPolicy(conflictResolutionPolicyJSON)
+			if err != nil {
+				return nil, err
+			}
+			properties.ConflictResolutionPolicy = conflictResolutionPolicy
+		}
+
+		ctx, span, start := startToolSpan(ctx, CREATE_CONTAINER_TOOL_NAME, account, database, container, "", "")
+		defer func() { endToolSpan(ctx, span, CREATE_CONTAINER_TOOL_NAME, start, 0, 0, err) }()
 
 		client, err := clientRetriever.Get(account)
 
@@ -209,28 +269,18 @@ func CreateContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.
 		if err != nil {
 			return nil, fmt.Errorf("error creating database client: %v", err)
 		}
-		// databaseClient, err := common.GetDatabaseClient(account, database)
-		// if err != nil {
-		// 	return nil, fmt.Errorf("error creating database client: %v", err)
-		// }
 
-		properties := azcosmos.ContainerProperties{
-			ID: container,
-			PartitionKeyDefinition: azcosmos.PartitionKeyDefinition{
-				Paths: []string{partitionKeyPath},
-				//Kind:  azcosmos.PartitionKeyKindHash,
-			},
-		}
-
-		if hasThroughput {
+		var createOptions *azcosmos.CreateContainerOptions
+		switch {
+		case hasThroughput:
 			throughputProps := azcosmos.NewManualThroughputProperties(int32(throughput))
-			_, err = databaseClient.CreateContainer(ctx, properties, &azcosmos.CreateContainerOptions{
-				ThroughputProperties: &throughputProps,
-			})
-		} else {
-			_, err = databaseClient.CreateContainer(ctx, properties, nil)
+			createOptions = &azcosmos.CreateContainerOptions{ThroughputProperties: &throughputProps}
+		case hasMaxThroughput:
+			throughputProps := azcosmos.NewAutoscaleThroughputProperties(int32(maxThroughput))
+			createOptions = &azcosmos.CreateContainerOptions{ThroughputProperties: &throughputProps}
 		}
 
+		_, err = databaseClient.CreateContainer(ctx, properties, createOptions)
 		if err != nil {
 			return nil, fmt.Errorf("error creating container: %v", err)
 		}
@@ -239,6 +289,168 @@ func CreateContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.
 	}
 }
 
+// partitionKeyPathsFromArguments resolves the container's partition key
+// path(s), accepting either the single-path "partitionKeyPath" argument or
+// the "partitionKeyPaths" JSON array argument used for hierarchical
+// (sub-)partition keys, e.g. ["/tenantId", "/userId"].
+func partitionKeyPathsFromArguments(arguments map[string]interface{}) ([]string, error) {
+	if pathsJSON, ok := arguments["partitionKeyPaths"].(string); ok && pathsJSON != "" {
+		var paths []string
+		if err := json.Unmarshal([]byte(pathsJSON), &paths); err != nil {
+			return nil, fmt.Errorf("error unmarshalling partitionKeyPaths JSON: %v", err)
+		}
+		if len(paths) == 0 {
+			return nil, errors.New("partitionKeyPaths must contain at least one path")
+		}
+		return paths, nil
+	}
+	if path, ok := arguments["partitionKeyPath"].(string); ok && path != "" {
+		return []string{path}, nil
+	}
+	return nil, errors.New("partition key path missing: either partitionKeyPath or partitionKeyPaths is required")
+}
+
+// containerIndexingPolicySpec is the wire shape of the indexingPolicy JSON
+// argument to CreateContainer, mirroring the indexing_policy block of the
+// Terraform azurerm_cosmosdb_sql_container resource.
+type containerIndexingPolicySpec struct {
+	Automatic        *bool                          `json:"automatic,omitempty"`
+	IndexingMode     string                         `json:"indexingMode,omitempty"`
+	IncludedPaths    []string                       `json:"includedPaths,omitempty"`
+	ExcludedPaths    []string                       `json:"excludedPaths,omitempty"`
+	CompositeIndexes [][]containerCompositePathSpec `json:"compositeIndexes,omitempty"`
+	SpatialIndexes   []containerSpatialIndexSpec    `json:"spatialIndexes,omitempty"`
+}
+
+type containerCompositePathSpec struct {
+	Path  string `json:"path"`
+	Order string `json:"order,omitempty"` // "ascending" (default) or "descending"
+}
+
+type containerSpatialIndexSpec struct {
+	Path  string   `json:"path"`
+	Types []string `json:"types,omitempty"` // Point, LineString, Polygon, MultiPolygon
+}
+
+// parseIndexingPolicy unmarshals the indexingPolicy JSON argument into an
+// azcosmos.IndexingPolicy.
+func parseIndexingPolicy(raw string) (*azcosmos.IndexingPolicy, error) {
+	var spec containerIndexingPolicySpec
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling indexingPolicy JSON: %v", err)
+	}
+
+	policy := &azcosmos.IndexingPolicy{
+		Automatic:    true,
+		IndexingMode: azcosmos.IndexingModeConsistent,
+	}
+	if spec.Automatic != nil {
+		policy.Automatic = *spec.Automatic
+	}
+	if spec.IndexingMode != "" {
+		switch spec.IndexingMode {
+		case "consistent":
+			policy.IndexingMode = azcosmos.IndexingModeConsistent
+		case "none":
+			policy.IndexingMode = azcosmos.IndexingModeNone
+		default:
+			return nil, fmt.Errorf("unsupported indexingMode %q, expected 'consistent' or 'none'", spec.IndexingMode)
+		}
+	}
+	for _, path := range spec.IncludedPaths {
+		policy.IncludedPaths = append(policy.IncludedPaths, azcosmos.IncludedPath{Path: path})
+	}
+	for _, path := range spec.ExcludedPaths {
+		policy.ExcludedPaths = append(policy.ExcludedPaths, azcosmos.ExcludedPath{Path: path})
+	}
+	for _, composite := range spec.CompositeIndexes {
+		var compositePaths []azcosmos.CompositePath
+		for _, path := range composite {
+			order := azcosmos.CompositeIndexOrderAscending
+			switch path.Order {
+			case "", "ascending":
+				order = azcosmos.CompositeIndexOrderAscending
+			case "descending":
+				order = azcosmos.CompositeIndexOrderDescending
+			default:
+				return nil, fmt.Errorf("unsupported composite index order %q, expected 'ascending' or 'descending'", path.Order)
+			}
+			compositePaths = append(compositePaths, azcosmos.CompositePath{Path: path.Path, Order: order})
+		}
+		policy.CompositeIndexes = append(policy.CompositeIndexes, compositePaths)
+	}
+	for _, spatial := range spec.SpatialIndexes {
+		var spatialTypes []azcosmos.SpatialType
+		for _, spatialType := range spatial.Types {
+			switch spatialType {
+			case "Point":
+				spatialTypes = append(spatialTypes, azcosmos.SpatialTypePoint)
+			case "LineString":
+				spatialTypes = append(spatialTypes, azcosmos.SpatialTypeLineString)
+			case "Polygon":
+				spatialTypes = append(spatialTypes, azcosmos.SpatialTypePolygon)
+			case "MultiPolygon":
+				spatialTypes = append(spatialTypes, azcosmos.SpatialTypeMultiPolygon)
+			default:
+				return nil, fmt.Errorf("unsupported spatial index type %q", spatialType)
+			}
+		}
+		policy.SpatialIndexes = append(policy.SpatialIndexes, azcosmos.SpatialIndex{Path: spatial.Path, Types: spatialTypes})
+	}
+
+	return policy, nil
+}
+
+// parseUniqueKeyPolicy unmarshals the uniqueKeyPolicy JSON argument - a list
+// of unique key path groups, e.g. [["/email"],["/tenantId","/ssn"]] - into an
+// azcosmos.UniqueKeyPolicy.
+func parseUniqueKeyPolicy(raw string) (*azcosmos.UniqueKeyPolicy, error) {
+	var uniqueKeyPaths [][]string
+	if err := json.Unmarshal([]byte(raw), &uniqueKeyPaths); err != nil {
+		return nil, fmt.Errorf("error unmarshalling uniqueKeyPolicy JSON: %v", err)
+	}
+
+	policy := &azcosmos.UniqueKeyPolicy{}
+	for _, paths := range uniqueKeyPaths {
+		if len(paths) == 0 {
+			return nil, errors.New("each unique key must specify at least one path")
+		}
+		policy.UniqueKeys = append(policy.UniqueKeys, azcosmos.UniqueKey{Paths: paths})
+	}
+	return policy, nil
+}
+
+// containerConflictResolutionPolicySpec is the wire shape of the
+// conflictResolutionPolicy JSON argument to CreateContainer.
+type containerConflictResolutionPolicySpec struct {
+	Mode                        string `json:"mode"` // "lastWriterWins" or "custom"
+	ConflictResolutionPath      string `json:"conflictResolutionPath,omitempty"`
+	ConflictResolutionProcedure string `json:"conflictResolutionProcedure,omitempty"`
+}
+
+// parseConflictResolutionPolicy unmarshals the conflictResolutionPolicy JSON
+// argument into an azcosmos.ConflictResolutionPolicy.
+func parseConflictResolutionPolicy(raw string) (*azcosmos.ConflictResolutionPolicy, error) {
+	var spec containerConflictResolutionPolicySpec
+	if err := json.Unmarshal([]byte(raw), &spec); err != nil {
+		return nil, fmt.Errorf("error unmarshalling conflictResolutionPolicy JSON: %v", err)
+	}
+
+	policy := &azcosmos.ConflictResolutionPolicy{
+		ConflictResolutionPath:      spec.ConflictResolutionPath,
+		ConflictResolutionProcedure: spec.ConflictResolutionProcedure,
+	}
+	switch spec.Mode {
+	case "lastWriterWins":
+		policy.Mode = azcosmos.ConflictResolutionModeLastWriterWins
+	case "custom":
+		policy.Mode = azcosmos.ConflictResolutionModeCustom
+	default:
+		return nil, fmt.Errorf("unsupported conflict resolution mode %q, expected 'lastWriterWins' or 'custom'", spec.Mode)
+	}
+	return policy, nil
+}
+
 func createContainer() mcp.Tool {
 	return mcp.NewTool(CREATE_CONTAINER_TOOL_NAME,
 		mcp.WithString("account",
@@ -254,18 +466,48 @@ func createContainer() mcp.Tool {
 			mcp.Description("Name of the container to create"),
 		),
 		mcp.WithString("partitionKeyPath",
-			mcp.Required(),
-			mcp.Description("Partition key path for the container, e.g., '/id'"),
+			mcp.Description("Partition key path for the container, e.g., '/id'. Either this or partitionKeyPaths is required."),
+		),
+		mcp.WithString("partitionKeyPaths",
+			mcp.Description("JSON array of partition key paths for a hierarchical (sub-)partition key, e.g. [\"/tenantId\",\"/userId\"], up to 3 levels. Either this or partitionKeyPath is required."),
 		),
 		mcp.WithNumber("throughput",
-			mcp.Description("Provisioned throughput for the container (optional)"),
+			mcp.Description("Manual provisioned throughput (RU/s) for the container (optional). Mutually exclusive with maxThroughput."),
+		),
+		mcp.WithNumber("maxThroughput",
+			mcp.Description("Max RU/s for autoscale provisioned throughput (optional). Mutually exclusive with throughput."),
+		),
+		mcp.WithNumber("defaultTtlSeconds",
+			mcp.Description("Default time-to-live for items in seconds (optional). -1 means items never expire unless they set their own ttl."),
+		),
+		mcp.WithString("indexingPolicy",
+			mcp.Description("JSON object describing the indexing policy, e.g. {\"indexingMode\":\"consistent\",\"includedPaths\":[\"/*\"],\"excludedPaths\":[\"/notIndexed/?\"],\"compositeIndexes\":[[{\"path\":\"/a\",\"order\":\"ascending\"},{\"path\":\"/b\",\"order\":\"descending\"}]],\"spatialIndexes\":[{\"path\":\"/location/*\",\"types\":[\"Point\"]}]} (optional, defaults to automatic consistent indexing of all paths)"),
 		),
-		mcp.WithDescription("Create a new container in a specified database"),
+		mcp.WithString("uniqueKeyPolicy",
+			mcp.Description("JSON array of unique key path groups enforced across the container, e.g. [[\"/email\"],[\"/tenantId\",\"/ssn\"]] (optional)"),
+		),
+		mcp.WithString("conflictResolutionPolicy",
+			mcp.Description("JSON object describing the conflict resolution policy, e.g. {\"mode\":\"lastWriterWins\",\"conflictResolutionPath\":\"/_ts\"} or {\"mode\":\"custom\",\"conflictResolutionProcedure\":\"dbs/mydb/colls/mycoll/sprocs/resolver\"} (optional, defaults to last-writer-wins on _ts)"),
+		),
+		mcp.WithDescription("Create a new container in a specified database, with the same expressiveness as the Terraform azurerm_cosmosdb_sql_container resource: hierarchical partition keys, manual or autoscale throughput, a full indexing policy, unique key constraints, default TTL and conflict resolution."),
 	)
 }
 
-func AddItemToContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
-	return addItemToContainer(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+// fetchPartitionKeyPath returns the container's partition key path (e.g.
+// "/department"), used to validate items before they're sent to Cosmos DB.
+func fetchPartitionKeyPath(ctx context.Context, containerClient *azcosmos.ContainerClient) (string, error) {
+	response, err := containerClient.Read(ctx, nil)
+	if err != nil {
+		return "", err
+	}
+	if len(response.ContainerProperties.PartitionKeyDefinition.Paths) == 0 {
+		return "", nil
+	}
+	return response.ContainerProperties.PartitionKeyDefinition.Paths[0], nil
+}
+
+func AddItemToContainer(clientRetriever CosmosDBClientRetriever, schemaRegistry *SchemaRegistry) (mcp.Tool, server.ToolHandlerFunc) {
+	return addItemToContainer(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
@@ -288,10 +530,14 @@ func AddItemToContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, serv
 			return nil, errors.New("item JSON missing")
 		}
 
-		// var item map[string]interface{}
-		// if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
-		// 	return nil, fmt.Errorf("error unmarshalling item JSON: %v", err)
-		// }
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
+			return nil, fmt.Errorf("error unmarshalling item JSON: %v", err)
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, ADD_CONTAINER_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, "")
+		defer func() { endToolSpan(ctx, span, ADD_CONTAINER_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
 
 		client, err := clientRetriever.Get(account)
 
@@ -310,16 +556,35 @@ func AddItemToContainer(clientRetriever CosmosDBClientRetriever) (mcp.Tool, serv
 			return nil, fmt.Errorf("error creating container client: %v", err)
 		}
 
+		partitionKeyPath, err := fetchPartitionKeyPath(ctx, containerClient)
+		if err != nil {
+			return nil, fmt.Errorf("error reading container metadata: %v", err)
+		}
+
+		violations := validateItemIdentity(item, partitionKeyPath)
+		if schema, ok := schemaRegistry.Get(account, database, container); ok {
+			violations = append(violations, validateItemAgainstSchema(item, schema)...)
+		}
+		if len(violations) > 0 {
+			jsonResult, err := json.Marshal(ItemValidationResponse{Valid: false, Violations: violations})
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling validation result to JSON: %v", err)
+			}
+			return mcp.NewToolResultText(string(jsonResult)), nil
+		}
+
 		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
-		// itemBytes, err := json.Marshal(item)
-		// if err != nil {
-		// 	return nil, fmt.Errorf("error marshalling item to JSON: %v", err)
-		// }
 
-		_, err = containerClient.CreateItem(ctx, partitionKey, []byte(itemJSON), nil)
-		if err != nil {
-			return nil, fmt.Errorf("error adding item to container: %v", err)
+		var createItemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var createErr error
+			createItemResponse, createErr = containerClient.CreateItem(ctx, partitionKey, []byte(itemJSON), nil)
+			return createErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error adding item to container", attempts+1, retryErr)
 		}
+		requestCharge = createItemResponse.RequestCharge
 
 		// var response map[string]interface{}
 		// err = json.Unmarshal(createItemResponse.Value, &response)
@@ -0,0 +1,291 @@
+package controlplane
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// accountLocationSpec is the wire shape of one entry in the locations JSON
+// argument to CreateCosmosAccount, mirroring `az cosmosdb create --locations`.
+type accountLocationSpec struct {
+	LocationName     string `json:"locationName"`
+	FailoverPriority int32  `json:"failoverPriority"`
+}
+
+func CreateCosmosAccount(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return createCosmosAccount(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		location, ok := request.Params.Arguments["location"].(string)
+		if !ok || location == "" {
+			return nil, errors.New("location missing")
+		}
+		freeTier, _ := request.Params.Arguments["enableFreeTier"].(bool)
+
+		locations := []*armcosmos.Location{{LocationName: to.Ptr(location), FailoverPriority: to.Ptr[int32](0)}}
+		if locationsJSON := argString(request, "locations"); locationsJSON != "" {
+			var specs []accountLocationSpec
+			if err := json.Unmarshal([]byte(locationsJSON), &specs); err != nil {
+				return nil, fmt.Errorf("error unmarshalling locations JSON: %v", err)
+			}
+			if len(specs) == 0 {
+				return nil, errors.New("locations must contain at least one entry")
+			}
+			locations = nil
+			for _, spec := range specs {
+				if spec.LocationName == "" {
+					return nil, errors.New("each location must specify locationName")
+				}
+				locations = append(locations, &armcosmos.Location{
+					LocationName:     to.Ptr(spec.LocationName),
+					FailoverPriority: to.Ptr(spec.FailoverPriority),
+				})
+			}
+		}
+
+		properties := &armcosmos.DatabaseAccountCreateUpdateProperties{
+			DatabaseAccountOfferType: to.Ptr("Standard"),
+			Locations:                locations,
+			EnableFreeTier:           to.Ptr(freeTier),
+		}
+
+		if consistencyLevel := argString(request, "consistencyLevel"); consistencyLevel != "" {
+			level := armcosmos.DefaultConsistencyLevel(consistencyLevel)
+			properties.ConsistencyPolicy = &armcosmos.ConsistencyPolicy{DefaultConsistencyLevel: to.Ptr(level)}
+		}
+
+		if publicNetworkAccess := argString(request, "publicNetworkAccess"); publicNetworkAccess != "" {
+			properties.PublicNetworkAccess = to.Ptr(armcosmos.PublicNetworkAccess(publicNetworkAccess))
+		}
+
+		if backupPolicyType := argString(request, "backupPolicyType"); backupPolicyType != "" {
+			switch backupPolicyType {
+			case string(armcosmos.BackupPolicyTypePeriodic):
+				properties.BackupPolicy = &armcosmos.PeriodicModeBackupPolicy{
+					Type: to.Ptr(armcosmos.BackupPolicyTypePeriodic),
+				}
+			case string(armcosmos.BackupPolicyTypeContinuous):
+				properties.BackupPolicy = &armcosmos.ContinuousModeBackupPolicy{
+					Type: to.Ptr(armcosmos.BackupPolicyTypeContinuous),
+				}
+			default:
+				return nil, fmt.Errorf("unsupported backupPolicyType %q, expected 'Periodic' or 'Continuous'", backupPolicyType)
+			}
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		accountsClient := clientFactory.NewDatabaseAccountsClient()
+
+		poller, err := accountsClient.BeginCreateOrUpdate(ctx, resourceGroup, accountName, armcosmos.DatabaseAccountCreateUpdateParameters{
+			Location:   to.Ptr(location),
+			Kind:       to.Ptr(armcosmos.DatabaseAccountKindGlobalDocumentDB),
+			Properties: properties,
+		}, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cosmos db account: %v", err)
+		}
+
+		result, err := poller.PollUntilDone(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for cosmos db account creation: %v", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Cosmos DB account '%s' created successfully in resource group '%s' (id: %s)", accountName, resourceGroup, *result.ID)), nil
+	}
+}
+
+func createCosmosAccount() mcp.Tool {
+	return mcp.NewTool(CREATE_COSMOS_ACCOUNT_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account to create"),
+		),
+		mcp.WithString("location",
+			mcp.Required(),
+			mcp.Description("Azure region for the account's primary write location, e.g. 'East US'"),
+		),
+		mcp.WithBoolean("enableFreeTier",
+			mcp.Description("Whether to enable the free tier discount for this account (optional, defaults to false)"),
+		),
+		mcp.WithString("locations",
+			mcp.Description("JSON array of every region of the account and its failover priority, replacing the single region at the location argument, e.g. [{\"locationName\":\"East US\",\"failoverPriority\":0},{\"locationName\":\"West US\",\"failoverPriority\":1}] (optional, defaults to a single region at the location argument with failover priority 0)"),
+		),
+		mcp.WithString("consistencyLevel",
+			mcp.Description("Default consistency level: 'Strong', 'BoundedStaleness', 'Session', 'Eventual' or 'ConsistentPrefix' (optional, account default applies if omitted)"),
+		),
+		mcp.WithString("publicNetworkAccess",
+			mcp.Description("'Enabled' or 'Disabled' (optional, account default applies if omitted)"),
+		),
+		mcp.WithString("backupPolicyType",
+			mcp.Description("'Periodic' or 'Continuous' (optional, account default applies if omitted)"),
+		),
+		mcp.WithDescription("Provision a new Cosmos DB account (SQL API) in the given resource group and region, with support for multi-region locations/failover priority, consistency level, free tier, public network access and backup policy - mirroring the options shown by `az cosmosdb create`. This is a management-plane operation and requires subscription-level permissions."),
+	)
+}
+
+type accountSummary struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+type listAccountsResponse struct {
+	Accounts []accountSummary `json:"accounts"`
+}
+
+func ListAccountsInSubscription(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return listAccountsInSubscription(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		accountsClient := clientFactory.NewDatabaseAccountsClient()
+
+		var accounts []accountSummary
+		pager := accountsClient.NewListPager(nil)
+		for pager.More() {
+			page, err := pager.NextPage(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("error listing cosmos db accounts: %v", err)
+			}
+			for _, account := range page.Value {
+				accounts = append(accounts, accountSummary{Name: *account.Name, Location: *account.Location})
+			}
+		}
+
+		jsonResult, err := json.Marshal(listAccountsResponse{Accounts: accounts})
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling result to JSON: %v", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+}
+
+func listAccountsInSubscription() mcp.Tool {
+	return mcp.NewTool(LIST_ACCOUNTS_IN_SUBSCRIPTION_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithDescription("List all Cosmos DB accounts visible in the given Azure subscription."),
+	)
+}
+
+func argString(request mcp.CallToolRequest, name string) string {
+	value, _ := request.Params.Arguments[name].(string)
+	return value
+}
+
+type accountKeysResponse struct {
+	PrimaryMasterKey           string `json:"primaryMasterKey"`
+	SecondaryMasterKey         string `json:"secondaryMasterKey"`
+	PrimaryReadonlyMasterKey   string `json:"primaryReadonlyMasterKey"`
+	SecondaryReadonlyMasterKey string `json:"secondaryReadonlyMasterKey"`
+}
+
+// ListAccountKeys retrieves the primary/secondary read-write and read-only
+// keys for a Cosmos DB account. Unlike the other management-plane tools,
+// this one returns credentials directly in the MCP result, the same way `az
+// cosmosdb keys list` does - callers must treat the response as sensitive.
+func ListAccountKeys(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return listAccountKeys(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		accountsClient := clientFactory.NewDatabaseAccountsClient()
+
+		keys, err := accountsClient.ListKeys(ctx, resourceGroup, accountName, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error listing account keys: %v", err)
+		}
+
+		response := accountKeysResponse{}
+		if keys.PrimaryMasterKey != nil {
+			response.PrimaryMasterKey = *keys.PrimaryMasterKey
+		}
+		if keys.SecondaryMasterKey != nil {
+			response.SecondaryMasterKey = *keys.SecondaryMasterKey
+		}
+		if keys.PrimaryReadonlyMasterKey != nil {
+			response.PrimaryReadonlyMasterKey = *keys.PrimaryReadonlyMasterKey
+		}
+		if keys.SecondaryReadonlyMasterKey != nil {
+			response.SecondaryReadonlyMasterKey = *keys.SecondaryReadonlyMasterKey
+		}
+
+		jsonResult, err := json.Marshal(response)
+		if err != nil {
+			return nil, fmt.Errorf("error marshalling result to JSON: %v", err)
+		}
+
+		return mcp.NewToolResultText(string(jsonResult)), nil
+	}
+}
+
+func listAccountKeys() mcp.Tool {
+	return mcp.NewTool(LIST_ACCOUNT_KEYS_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account"),
+		),
+		mcp.WithDescription("Retrieve the primary/secondary read-write and read-only keys for a Cosmos DB account. The response contains live credentials; handle it as sensitive."),
+	)
+}
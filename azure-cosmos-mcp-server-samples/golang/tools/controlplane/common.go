@@ -0,0 +1,64 @@
+// Package controlplane exposes MCP tools backed by the Cosmos DB
+// management (ARM) plane, as opposed to the data-plane tools in the
+// parent tools package. It uses armcosmos to provision and configure
+// accounts, databases and containers, mirroring what infra tooling such
+// as Terraform or the az CLI offers.
+package controlplane
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+)
+
+const SUBSCRIPTION_ID_PARAMETER_DESCRIPTION = "Azure subscription ID. If not available, ask the user to provide it, or set the AZURE_SUBSCRIPTION_ID environment variable."
+const RESOURCE_GROUP_PARAMETER_DESCRIPTION = "Name of the Azure resource group."
+
+const CREATE_COSMOS_ACCOUNT_TOOL_NAME = "create_cosmos_account"
+const LIST_ACCOUNTS_IN_SUBSCRIPTION_TOOL_NAME = "list_accounts_in_subscription"
+const LIST_ACCOUNT_KEYS_TOOL_NAME = "list_account_keys"
+const CREATE_SQL_DATABASE_ARM_TOOL_NAME = "create_sql_database_arm"
+const DELETE_DATABASE_TOOL_NAME = "delete_database"
+const CREATE_SQL_CONTAINER_ARM_TOOL_NAME = "create_sql_container_arm"
+const UPDATE_THROUGHPUT_TOOL_NAME = "update_throughput"
+
+// CosmosDBManagementClientRetriever resolves the ARM clients needed to
+// manage Cosmos DB accounts, databases and containers for a given
+// subscription.
+type CosmosDBManagementClientRetriever interface {
+	Get(subscriptionID string) (*armcosmos.ClientFactory, error)
+}
+
+// ControlPlaneClientRetriever is the production CosmosDBManagementClientRetriever,
+// authenticating with azidentity.NewDefaultAzureCredential so it works with
+// managed identity, workload identity or developer sign-in.
+type ControlPlaneClientRetriever struct {
+}
+
+func (retriever ControlPlaneClientRetriever) Get(subscriptionID string) (*armcosmos.ClientFactory, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating credential: %v", err)
+	}
+
+	clientFactory, err := armcosmos.NewClientFactory(subscriptionID, cred, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating ARM client factory: %v", err)
+	}
+
+	return clientFactory, nil
+}
+
+// resolveSubscriptionID returns the subscription ID argument if present,
+// falling back to the AZURE_SUBSCRIPTION_ID environment variable.
+func resolveSubscriptionID(subscriptionID string) (string, error) {
+	if subscriptionID != "" {
+		return subscriptionID, nil
+	}
+	if envValue := os.Getenv("AZURE_SUBSCRIPTION_ID"); envValue != "" {
+		return envValue, nil
+	}
+	return "", fmt.Errorf("azure subscription ID missing")
+}
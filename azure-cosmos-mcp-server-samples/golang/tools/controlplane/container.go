@@ -0,0 +1,147 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func CreateSQLContainerARM(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return createSQLContainerARM(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		partitionKeyPath, ok := request.Params.Arguments["partitionKeyPath"].(string)
+		if !ok || partitionKeyPath == "" {
+			return nil, errors.New("partition key path missing")
+		}
+		autoscaleMaxThroughput, hasAutoscale := request.Params.Arguments["autoscaleMaxThroughput"].(float64)
+		defaultTTL, hasTTL := request.Params.Arguments["defaultTTL"].(float64)
+		uniqueKeyPaths, _ := request.Params.Arguments["uniqueKeyPaths"].([]interface{})
+		paths := make([]*string, 0, len(uniqueKeyPaths))
+		for _, path := range uniqueKeyPaths {
+			pathStr, ok := path.(string)
+			if !ok {
+				return nil, errors.New("uniqueKeyPaths must be an array of strings")
+			}
+			paths = append(paths, to.Ptr(pathStr))
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		containerClient := clientFactory.NewSQLResourcesClient()
+
+		resource := &armcosmos.SQLContainerResource{
+			ID: to.Ptr(container),
+			PartitionKey: &armcosmos.ContainerPartitionKey{
+				Paths: []*string{to.Ptr(partitionKeyPath)},
+				Kind:  to.Ptr(armcosmos.PartitionKindHash),
+			},
+			IndexingPolicy: &armcosmos.IndexingPolicy{
+				Automatic:     to.Ptr(true),
+				IndexingMode:  to.Ptr(armcosmos.IndexingModeConsistent),
+				IncludedPaths: []*armcosmos.IncludedPath{{Path: to.Ptr("/*")}},
+			},
+		}
+
+		if hasTTL {
+			resource.DefaultTTL = to.Ptr(int32(defaultTTL))
+		}
+
+		if len(paths) > 0 {
+			resource.UniqueKeyPolicy = &armcosmos.UniqueKeyPolicy{
+				UniqueKeys: []*armcosmos.UniqueKey{{Paths: paths}},
+			}
+		}
+
+		properties := armcosmos.SQLContainerCreateUpdateParameters{
+			Properties: &armcosmos.SQLContainerCreateUpdateProperties{
+				Resource: resource,
+			},
+		}
+
+		if hasAutoscale {
+			properties.Properties.Options = &armcosmos.CreateUpdateOptions{
+				AutoscaleSettings: &armcosmos.AutoscaleSettings{
+					MaxThroughput: to.Ptr(int32(autoscaleMaxThroughput)),
+				},
+			}
+		}
+
+		poller, err := containerClient.BeginCreateUpdateSQLContainer(ctx, resourceGroup, accountName, database, container, properties, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating sql container: %v", err)
+		}
+
+		_, err = poller.PollUntilDone(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for sql container creation: %v", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Container '%s' created successfully in database '%s' (account '%s')", container, database, accountName)), nil
+	}
+}
+
+func createSQLContainerARM() mcp.Tool {
+	return mcp.NewTool(CREATE_SQL_CONTAINER_ARM_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account"),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database to create the container in"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container to create"),
+		),
+		mcp.WithString("partitionKeyPath",
+			mcp.Required(),
+			mcp.Description("Partition key path for the container, e.g., '/id'"),
+		),
+		mcp.WithNumber("autoscaleMaxThroughput",
+			mcp.Description("Autoscale max RU/s dedicated to this container (optional)"),
+		),
+		mcp.WithNumber("defaultTTL",
+			mcp.Description("Default time-to-live in seconds for items in the container (optional, -1 disables expiry, omit to leave TTL off)"),
+		),
+		mcp.WithArray("uniqueKeyPaths",
+			mcp.Description("Optional array of property paths (e.g. ['/email']) that together form one unique key: the combination of their values must be unique within each partition"),
+		),
+		mcp.WithDescription("Create a SQL API container under a Cosmos DB database using the management (ARM) plane, with support for autoscale throughput, default TTL and a unique key policy. Mirrors the options exposed by the azurerm_cosmosdb_sql_container Terraform resource."),
+	)
+}
@@ -0,0 +1,204 @@
+package controlplane
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCallToolRequest builds an mcp.CallToolRequest carrying arguments, the
+// same way the tests in the parent tools package do.
+func newCallToolRequest(arguments map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: arguments,
+		},
+	}
+}
+
+func assertToolSchema(t *testing.T, tool mcp.Tool, wantName string, wantProperties, wantRequired []string) {
+	t.Helper()
+
+	assert.Equal(t, wantName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	for _, property := range wantProperties {
+		assert.Contains(t, tool.InputSchema.Properties, property)
+	}
+	assert.ElementsMatch(t, wantRequired, tool.InputSchema.Required)
+}
+
+func TestCreateCosmosAccountUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := CreateCosmosAccount(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, CREATE_COSMOS_ACCOUNT_TOOL_NAME,
+		[]string{"resourceGroup", "account", "location"},
+		[]string{"resourceGroup", "account", "location"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "", "location": "East US",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cosmos db account name missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "location": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "location missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "location": "East US", "locations": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling locations JSON")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "location": "East US", "backupPolicyType": "Nightly",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported backupPolicyType")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "location": "East US",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error creating ARM client")
+}
+
+func TestListAccountsInSubscriptionUnit(t *testing.T) {
+	os.Unsetenv("AZURE_SUBSCRIPTION_ID")
+
+	tool, handler := ListAccountsInSubscription(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, LIST_ACCOUNTS_IN_SUBSCRIPTION_TOOL_NAME, nil, nil)
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "azure subscription ID missing")
+}
+
+func TestListAccountKeysUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := ListAccountKeys(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, LIST_ACCOUNT_KEYS_TOOL_NAME,
+		[]string{"resourceGroup", "account"},
+		[]string{"resourceGroup", "account"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "", "account": "acct",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "resource group name missing")
+}
+
+func TestCreateSQLDatabaseARMUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := CreateSQLDatabaseARM(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, CREATE_SQL_DATABASE_ARM_TOOL_NAME,
+		[]string{"resourceGroup", "account", "database", "autoscaleMaxThroughput"},
+		[]string{"resourceGroup", "account", "database"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database name missing")
+
+	// autoscaleMaxThroughput arrives as a JSON number, i.e. float64 - as
+	// mcp.WithNumber always decodes it - and must not be rejected or panic.
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db", "autoscaleMaxThroughput": float64(4000),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error creating ARM client")
+}
+
+func TestDeleteSQLDatabaseARMUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := DeleteSQLDatabaseARM(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, DELETE_DATABASE_TOOL_NAME,
+		[]string{"resourceGroup", "account", "database"},
+		[]string{"resourceGroup", "account", "database"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "", "database": "db",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cosmos db account name missing")
+}
+
+func TestCreateSQLContainerARMUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := CreateSQLContainerARM(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, CREATE_SQL_CONTAINER_ARM_TOOL_NAME,
+		[]string{"resourceGroup", "account", "database", "container", "partitionKeyPath", "autoscaleMaxThroughput", "defaultTTL", "uniqueKeyPaths"},
+		[]string{"resourceGroup", "account", "database", "container", "partitionKeyPath"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db", "container": "c", "partitionKeyPath": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partition key path missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db", "container": "c", "partitionKeyPath": "/id",
+		"uniqueKeyPaths": []interface{}{float64(1)},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "uniqueKeyPaths must be an array of strings")
+
+	// autoscaleMaxThroughput and defaultTTL arrive as float64, not int -
+	// must be recognized rather than silently ignored.
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db", "container": "c", "partitionKeyPath": "/id",
+		"autoscaleMaxThroughput": float64(4000), "defaultTTL": float64(-1),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error creating ARM client")
+}
+
+func TestUpdateThroughputUnit(t *testing.T) {
+	t.Setenv("AZURE_SUBSCRIPTION_ID", "00000000-0000-0000-0000-000000000000")
+	tool, handler := UpdateThroughput(fakeManagementClientRetriever{})
+
+	assertToolSchema(t, tool, UPDATE_THROUGHPUT_TOOL_NAME,
+		[]string{"resourceGroup", "account", "database", "container", "throughput", "autoscaleMaxThroughput"},
+		[]string{"resourceGroup", "account", "database"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of throughput or autoscaleMaxThroughput is required")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db",
+		"throughput": float64(400), "autoscaleMaxThroughput": float64(4000),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exactly one of throughput or autoscaleMaxThroughput is required")
+
+	// A single float64 throughput value - as mcp.WithNumber always decodes
+	// it - must be recognized as satisfying the "exactly one" requirement.
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"resourceGroup": "rg", "account": "acct", "database": "db", "throughput": float64(400),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error creating ARM client")
+}
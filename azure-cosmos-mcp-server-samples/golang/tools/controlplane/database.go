@@ -0,0 +1,158 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+func CreateSQLDatabaseARM(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return createSQLDatabaseARM(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		autoscaleMaxThroughput, hasAutoscale := request.Params.Arguments["autoscaleMaxThroughput"].(float64)
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		databaseClient := clientFactory.NewSQLResourcesClient()
+
+		properties := armcosmos.SQLDatabaseCreateUpdateParameters{
+			Properties: &armcosmos.SQLDatabaseCreateUpdateProperties{
+				Resource: &armcosmos.SQLDatabaseResource{
+					ID: to.Ptr(database),
+				},
+			},
+		}
+
+		if hasAutoscale {
+			properties.Properties.Options = &armcosmos.CreateUpdateOptions{
+				AutoscaleSettings: &armcosmos.AutoscaleSettings{
+					MaxThroughput: to.Ptr(int32(autoscaleMaxThroughput)),
+				},
+			}
+		}
+
+		poller, err := databaseClient.BeginCreateUpdateSQLDatabase(ctx, resourceGroup, accountName, database, properties, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating sql database: %v", err)
+		}
+
+		_, err = poller.PollUntilDone(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for sql database creation: %v", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Database '%s' created successfully in account '%s'", database, accountName)), nil
+	}
+}
+
+// DeleteSQLDatabaseARM deletes a SQL API database, and every container it
+// holds, via the management (ARM) plane.
+func DeleteSQLDatabaseARM(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return deleteSQLDatabaseARM(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		databaseClient := clientFactory.NewSQLResourcesClient()
+
+		poller, err := databaseClient.BeginDeleteSQLDatabase(ctx, resourceGroup, accountName, database, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error deleting sql database: %v", err)
+		}
+
+		_, err = poller.PollUntilDone(ctx, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error waiting for sql database deletion: %v", err)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Database '%s' deleted successfully from account '%s'", database, accountName)), nil
+	}
+}
+
+func deleteSQLDatabaseARM() mcp.Tool {
+	return mcp.NewTool(DELETE_DATABASE_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account"),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the SQL database to delete, along with every container it holds"),
+		),
+		mcp.WithDescription("Delete a SQL API database, and every container it holds, from a Cosmos DB account using the management (ARM) plane. This is irreversible."),
+	)
+}
+
+func createSQLDatabaseARM() mcp.Tool {
+	return mcp.NewTool(CREATE_SQL_DATABASE_ARM_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account"),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the SQL database to create"),
+		),
+		mcp.WithNumber("autoscaleMaxThroughput",
+			mcp.Description("Autoscale max RU/s for the database (optional, shared by all containers that don't set their own throughput)"),
+		),
+		mcp.WithDescription("Create a SQL API database under a Cosmos DB account using the management (ARM) plane, optionally with shared autoscale throughput."),
+	)
+}
@@ -0,0 +1,23 @@
+package controlplane
+
+import (
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+)
+
+// fakeManagementClientRetriever is an in-memory CosmosDBManagementClientRetriever
+// for unit tests that don't have a subscription to call ARM against. Get
+// always fails with Err (or a generic error if Err is unset), which is
+// enough to exercise argument-validation and client-creation-error paths in
+// tool handlers without ever dialing out to Azure Resource Manager.
+type fakeManagementClientRetriever struct {
+	Err error
+}
+
+func (retriever fakeManagementClientRetriever) Get(subscriptionID string) (*armcosmos.ClientFactory, error) {
+	if retriever.Err != nil {
+		return nil, retriever.Err
+	}
+	return nil, errors.New("fakeManagementClientRetriever: no client configured for unit tests")
+}
@@ -0,0 +1,114 @@
+package controlplane
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/resourcemanager/cosmos/armcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+// UpdateThroughput updates the manual or autoscale provisioned throughput of
+// a SQL database, or - when container is also given - of one of its
+// containers, via the management (ARM) plane.
+func UpdateThroughput(clientRetriever CosmosDBManagementClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return updateThroughput(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		subscriptionID, err := resolveSubscriptionID(argString(request, "subscriptionId"))
+		if err != nil {
+			return nil, err
+		}
+		resourceGroup, ok := request.Params.Arguments["resourceGroup"].(string)
+		if !ok || resourceGroup == "" {
+			return nil, errors.New("resource group name missing")
+		}
+		accountName, ok := request.Params.Arguments["account"].(string)
+		if !ok || accountName == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container := argString(request, "container")
+
+		manualThroughput, hasManual := request.Params.Arguments["throughput"].(float64)
+		autoscaleMaxThroughput, hasAutoscale := request.Params.Arguments["autoscaleMaxThroughput"].(float64)
+		if hasManual == hasAutoscale {
+			return nil, errors.New("exactly one of throughput or autoscaleMaxThroughput is required")
+		}
+
+		resource := &armcosmos.ThroughputSettingsResource{}
+		if hasManual {
+			resource.Throughput = to.Ptr(int32(manualThroughput))
+		} else {
+			resource.AutoscaleSettings = &armcosmos.AutoscaleSettingsResource{MaxThroughput: to.Ptr(int32(autoscaleMaxThroughput))}
+		}
+
+		clientFactory, err := clientRetriever.Get(subscriptionID)
+		if err != nil {
+			return nil, fmt.Errorf("error creating ARM client: %v", err)
+		}
+
+		resourcesClient := clientFactory.NewSQLResourcesClient()
+		parameters := armcosmos.ThroughputSettingsUpdateParameters{
+			Properties: &armcosmos.ThroughputSettingsUpdateProperties{Resource: resource},
+		}
+
+		var scope string
+		if container != "" {
+			poller, err := resourcesClient.BeginUpdateSQLContainerThroughput(ctx, resourceGroup, accountName, database, container, parameters, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error updating container throughput: %v", err)
+			}
+			if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+				return nil, fmt.Errorf("error waiting for container throughput update: %v", err)
+			}
+			scope = fmt.Sprintf("container '%s' in database '%s'", container, database)
+		} else {
+			poller, err := resourcesClient.BeginUpdateSQLDatabaseThroughput(ctx, resourceGroup, accountName, database, parameters, nil)
+			if err != nil {
+				return nil, fmt.Errorf("error updating database throughput: %v", err)
+			}
+			if _, err := poller.PollUntilDone(ctx, nil); err != nil {
+				return nil, fmt.Errorf("error waiting for database throughput update: %v", err)
+			}
+			scope = fmt.Sprintf("database '%s'", database)
+		}
+
+		return mcp.NewToolResultText(fmt.Sprintf("Throughput updated successfully for %s (account '%s')", scope, accountName)), nil
+	}
+}
+
+func updateThroughput() mcp.Tool {
+	return mcp.NewTool(UPDATE_THROUGHPUT_TOOL_NAME,
+		mcp.WithString("subscriptionId",
+			mcp.Description(SUBSCRIPTION_ID_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("resourceGroup",
+			mcp.Required(),
+			mcp.Description(RESOURCE_GROUP_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description("Name of the Cosmos DB account"),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the SQL database whose throughput - or, when container is given, whose container's throughput - to update"),
+		),
+		mcp.WithString("container",
+			mcp.Description("Name of the container to update throughput for (optional; omit to update shared database-level throughput instead)"),
+		),
+		mcp.WithNumber("throughput",
+			mcp.Description("New manual provisioned throughput (RU/s). Exactly one of throughput or autoscaleMaxThroughput is required."),
+		),
+		mcp.WithNumber("autoscaleMaxThroughput",
+			mcp.Description("New autoscale max RU/s. Exactly one of throughput or autoscaleMaxThroughput is required."),
+		),
+		mcp.WithDescription("Update the manual or autoscale provisioned throughput of a database, or one of its containers, using the management (ARM) plane."),
+	)
+}
@@ -0,0 +1,155 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azidentity"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/Azure/azure-sdk-for-go/sdk/keyvault/azsecrets"
+)
+
+// Credential builds a Cosmos DB client for an account using one specific
+// authentication approach. Implementations that carry their own endpoint
+// (e.g. a connection string) are free to ignore accountName.
+type Credential interface {
+	NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error)
+}
+
+// cosmosEndpoint builds the default Cosmos DB SQL API endpoint for an
+// account name.
+func cosmosEndpoint(accountName string) string {
+	return fmt.Sprintf("https://%s.documents.azure.com:443/", accountName)
+}
+
+// KeyCredential authenticates with a Cosmos DB primary/secondary account
+// key. AccountKey falls back to the COSMOSDB_ACCOUNT_KEY env var when unset,
+// matching the env-driven configuration the rest of this package uses.
+type KeyCredential struct {
+	AccountKey string
+}
+
+func (credential KeyCredential) NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error) {
+	accountKey := credential.AccountKey
+	if accountKey == "" {
+		accountKey = os.Getenv("COSMOSDB_ACCOUNT_KEY")
+	}
+	if accountKey == "" {
+		return nil, errors.New("no account key configured: set KeyCredential.AccountKey or the COSMOSDB_ACCOUNT_KEY env var")
+	}
+
+	keyCredential, err := azcosmos.NewKeyCredential(accountKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating key credential: %v", err)
+	}
+
+	client, err := azcosmos.NewClientWithKey(cosmosEndpoint(accountName), keyCredential, options)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cosmos client: %v", err)
+	}
+
+	return client, nil
+}
+
+// AADCredential authenticates exclusively via Microsoft Entra ID (Azure AD),
+// using azidentity.NewDefaultAzureCredential. This supports managed
+// identity, workload identity and developer sign-in, and is useful in
+// environments where key-based auth has been disabled on the account.
+type AADCredential struct {
+}
+
+func (credential AADCredential) NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error) {
+	cred, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AAD credential: %v", err)
+	}
+
+	client, err := azcosmos.NewClient(cosmosEndpoint(accountName), cred, options)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cosmos client: %v", err)
+	}
+
+	return client, nil
+}
+
+// ConnectionStringCredential authenticates using a full Cosmos DB
+// connection string (AccountEndpoint=...;AccountKey=...), which already
+// carries the endpoint, so accountName is ignored. ConnectionString falls
+// back to the COSMOSDB_CONNECTION_STRING env var when unset.
+type ConnectionStringCredential struct {
+	ConnectionString string
+}
+
+func (credential ConnectionStringCredential) NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error) {
+	connectionString := credential.ConnectionString
+	if connectionString == "" {
+		connectionString = os.Getenv("COSMOSDB_CONNECTION_STRING")
+	}
+	if connectionString == "" {
+		return nil, errors.New("no connection string configured: set ConnectionStringCredential.ConnectionString or the COSMOSDB_CONNECTION_STRING env var")
+	}
+
+	client, err := azcosmos.NewClientFromConnectionString(connectionString, options)
+	if err != nil {
+		return nil, fmt.Errorf("error creating Cosmos client from connection string: %v", err)
+	}
+
+	return client, nil
+}
+
+// KeyVaultKeyCredential fetches the Cosmos DB account key from an Azure Key
+// Vault secret, then authenticates the same way KeyCredential does; a secret
+// starting with "AccountEndpoint=" is used as a connection string instead,
+// the same way ConnectionStringCredential does. This
+// follows the azure-dev pattern of storing the account key (or a connection
+// string) in a vault instead of injecting it directly via environment
+// variables. VaultURI and SecretName fall back to the COSMOSDB_KEYVAULT_URI
+// and COSMOSDB_KEYVAULT_SECRET_NAME env vars when unset. Access to the vault
+// itself is always via azidentity.NewDefaultAzureCredential.
+type KeyVaultKeyCredential struct {
+	VaultURI   string
+	SecretName string
+}
+
+func (credential KeyVaultKeyCredential) NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error) {
+	vaultURI := credential.VaultURI
+	if vaultURI == "" {
+		vaultURI = os.Getenv("COSMOSDB_KEYVAULT_URI")
+	}
+	if vaultURI == "" {
+		return nil, errors.New("no key vault configured: set KeyVaultKeyCredential.VaultURI or the COSMOSDB_KEYVAULT_URI env var")
+	}
+	secretName := credential.SecretName
+	if secretName == "" {
+		secretName = os.Getenv("COSMOSDB_KEYVAULT_SECRET_NAME")
+	}
+	if secretName == "" {
+		return nil, errors.New("no key vault secret name configured: set KeyVaultKeyCredential.SecretName or the COSMOSDB_KEYVAULT_SECRET_NAME env var")
+	}
+
+	aadCredential, err := azidentity.NewDefaultAzureCredential(nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating AAD credential for key vault access: %v", err)
+	}
+
+	secretsClient, err := azsecrets.NewClient(vaultURI, aadCredential, nil)
+	if err != nil {
+		return nil, fmt.Errorf("error creating key vault client: %v", err)
+	}
+
+	secret, err := secretsClient.GetSecret(context.Background(), secretName, "", nil)
+	if err != nil {
+		return nil, fmt.Errorf("error fetching secret %q from key vault %q: %v", secretName, vaultURI, err)
+	}
+	if secret.Value == nil || *secret.Value == "" {
+		return nil, fmt.Errorf("secret %q in key vault %q has no value", secretName, vaultURI)
+	}
+
+	if strings.HasPrefix(*secret.Value, "AccountEndpoint=") {
+		return ConnectionStringCredential{ConnectionString: *secret.Value}.NewClient(accountName, options)
+	}
+	return KeyCredential{AccountKey: *secret.Value}.NewClient(accountName, options)
+}
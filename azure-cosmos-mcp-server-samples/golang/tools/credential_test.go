@@ -0,0 +1,71 @@
+package tools
+
+import (
+	"os"
+	"testing"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyCredentialUnit(t *testing.T) {
+	os.Unsetenv("COSMOSDB_ACCOUNT_KEY")
+
+	_, err := KeyCredential{}.NewClient("acct", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no account key configured")
+
+	_, err = KeyCredential{AccountKey: "not-a-valid-base64-key"}.NewClient("acct", nil)
+	require.Error(t, err)
+}
+
+func TestConnectionStringCredentialUnit(t *testing.T) {
+	os.Unsetenv("COSMOSDB_CONNECTION_STRING")
+
+	_, err := ConnectionStringCredential{}.NewClient("acct", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no connection string configured")
+}
+
+func TestKeyVaultKeyCredentialUnit(t *testing.T) {
+	os.Unsetenv("COSMOSDB_KEYVAULT_URI")
+	os.Unsetenv("COSMOSDB_KEYVAULT_SECRET_NAME")
+
+	_, err := KeyVaultKeyCredential{}.NewClient("acct", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no key vault configured")
+
+	_, err = KeyVaultKeyCredential{VaultURI: "https://example.vault.azure.net"}.NewClient("acct", nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no key vault secret name configured")
+}
+
+// countingCredential counts how many times NewClient is invoked, so tests
+// can assert PooledClientRetriever only builds a client once per account.
+type countingCredential struct {
+	calls int
+}
+
+func (credential *countingCredential) NewClient(accountName string, options *azcosmos.ClientOptions) (*azcosmos.Client, error) {
+	credential.calls++
+	return &azcosmos.Client{}, nil
+}
+
+func TestPooledClientRetrieverUnit(t *testing.T) {
+	credential := &countingCredential{}
+	retriever := &PooledClientRetriever{Credential: credential}
+
+	first, err := retriever.Get("acct")
+	require.NoError(t, err)
+
+	second, err := retriever.Get("acct")
+	require.NoError(t, err)
+
+	assert.Same(t, first, second)
+	assert.Equal(t, 1, credential.calls)
+
+	_, err = retriever.Get("other-acct")
+	require.NoError(t, err)
+	assert.Equal(t, 2, credential.calls)
+}
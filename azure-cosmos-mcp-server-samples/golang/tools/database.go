@@ -6,7 +6,7 @@ import (
 	"errors"
 	"fmt"
 
-	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
@@ -14,13 +14,16 @@ import (
 func ListDatabases(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
 	//func ListDatabases() (mcp.Tool, server.ToolHandlerFunc) {
 
-	return listDatabases(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return listDatabases(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
 			return nil, errors.New("cosmos db account name missing")
 		}
 
+		ctx, span, start := startToolSpan(ctx, LIST_DATABASES_TOOL_NAME, account, "", "", "", "")
+		defer func() { endToolSpan(ctx, span, LIST_DATABASES_TOOL_NAME, start, 0, 0, err) }()
+
 		client, err := clientRetriever.Get(account)
 		//client, err := common.GetCosmosDBClient(account)
 
@@ -33,12 +36,16 @@ func ListDatabases(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.To
 
 		queryPager := client.NewQueryDatabasesPager("select * from dbs d", nil)
 
+		retryPolicy := RetryPolicyFromEnv()
 		for queryPager.More() {
-			queryResponse, err := queryPager.NextPage(context.Background())
-			if err != nil {
-				var responseErr *azcore.ResponseError
-				errors.As(err, &responseErr)
-				return nil, err
+			var queryResponse azcosmos.QueryDatabasesResponse
+			attempts, _, retryErr := retryOnThrottle(ctx, retryPolicy, func() error {
+				var pageErr error
+				queryResponse, pageErr = queryPager.NextPage(ctx)
+				return pageErr
+			})
+			if retryErr != nil {
+				return nil, wrapRetryExhausted("error listing databases", attempts+1, retryErr)
 			}
 
 			for _, db := range queryResponse.Databases {
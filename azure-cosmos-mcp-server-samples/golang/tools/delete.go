@@ -0,0 +1,100 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const DELETE_ITEM_TOOL_NAME = "delete_item"
+
+func DeleteItem(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return deleteItem(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		itemID, ok := request.Params.Arguments["itemID"].(string)
+		if !ok || itemID == "" {
+			return nil, errors.New("item ID missing")
+		}
+		partitionKeyValue, ok := request.Params.Arguments["partitionKey"].(string)
+		if !ok || partitionKeyValue == "" {
+			return nil, errors.New("partition key missing")
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, DELETE_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, itemID)
+		defer func() { endToolSpan(ctx, span, DELETE_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+
+		var itemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var deleteErr error
+			itemResponse, deleteErr = containerClient.DeleteItem(ctx, partitionKey, itemID, nil)
+			return deleteErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error deleting item", attempts+1, retryErr)
+		}
+		requestCharge = itemResponse.RequestCharge
+
+		return mcp.NewToolResultText(fmt.Sprintf("Item '%s' deleted successfully from container '%s' in database '%s'", itemID, container, database)), nil
+	}
+}
+
+func deleteItem() mcp.Tool {
+	return mcp.NewTool(DELETE_ITEM_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container holding the item"),
+		),
+		mcp.WithString("itemID",
+			mcp.Required(),
+			mcp.Description("ID of the item to delete"),
+		),
+		mcp.WithString("partitionKey",
+			mcp.Required(),
+			mcp.Description("Partition key of the item to delete"),
+		),
+		mcp.WithDescription("Delete a specific item from a container in a Cosmos DB database"),
+	)
+}
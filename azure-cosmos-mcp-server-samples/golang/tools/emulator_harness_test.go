@@ -0,0 +1,144 @@
+//go:build integration
+
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/stretchr/testify/require"
+)
+
+// EmulatorHarness gives each test its own uniquely-named container against
+// the shared emulator started in TestMain, instead of every test sharing
+// testOperationContainerName, where one test's writes are visible to
+// another's reads. The handler tests in tools_test.go use it via
+// WithContainer, which is what lets them run with t.Parallel(). EmulatorHarness, and the Snapshot/Restore
+// pair below, are meant to be reused by downstream MCP tool tests written
+// against a real emulator, not just by this package.
+type EmulatorHarness struct {
+	client *azcosmos.Client
+}
+
+// NewEmulatorHarness wraps a Cosmos DB client obtained from
+// CosmosDBEmulatorClientRetriever (or any other emulator-backed retriever).
+func NewEmulatorHarness(client *azcosmos.Client) *EmulatorHarness {
+	return &EmulatorHarness{client: client}
+}
+
+var nonAlphanumeric = regexp.MustCompile(`[^a-zA-Z0-9]+`)
+
+// WithContainer creates a container in testOperationDBName named after t,
+// with a real partition key path, passes it to fn, and deletes the
+// container via t.Cleanup once the test (and any subtests) finish. Every
+// call gets its own container, so tests using WithContainer are safe to run
+// with t.Parallel().
+func (harness *EmulatorHarness) WithContainer(t *testing.T, fn func(container *azcosmos.ContainerClient)) {
+	t.Helper()
+	ctx := context.Background()
+
+	database, err := harness.client.NewDatabase(testOperationDBName)
+	require.NoError(t, err)
+
+	containerID := fmt.Sprintf("t-%s-%d", strings.ToLower(nonAlphanumeric.ReplaceAllString(t.Name(), "-")), time.Now().UnixNano())
+	_, err = database.CreateContainer(ctx, azcosmos.ContainerProperties{
+		ID: containerID,
+		PartitionKeyDefinition: azcosmos.PartitionKeyDefinition{
+			Paths: []string{testPartitionKey},
+		},
+		DefaultTimeToLive: to.Ptr[int32](60),
+	}, nil)
+	require.NoError(t, err)
+
+	containerClient, err := database.NewContainer(containerID)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		_, _ = containerClient.Delete(context.Background(), nil)
+	})
+
+	fn(containerClient)
+}
+
+// ContainerSnapshot is a point-in-time dump of every item in a container,
+// captured by Snapshot and reapplied by Restore so a subtest's writes can be
+// undone without tearing down and recreating the whole container.
+type ContainerSnapshot struct {
+	partitionKeyField string
+	items             []json.RawMessage
+}
+
+// Snapshot reads every item currently in containerClient via a
+// cross-partition query.
+func Snapshot(ctx context.Context, containerClient *azcosmos.ContainerClient) (ContainerSnapshot, error) {
+	partitionKeyPath, err := fetchPartitionKeyPath(ctx, containerClient)
+	if err != nil {
+		return ContainerSnapshot{}, fmt.Errorf("error reading container metadata: %v", err)
+	}
+
+	var items []json.RawMessage
+	pager := containerClient.NewQueryItemsPager("SELECT * FROM c", azcosmos.PartitionKey{}, nil)
+	for pager.More() {
+		page, err := pager.NextPage(ctx)
+		if err != nil {
+			return ContainerSnapshot{}, fmt.Errorf("error querying items: %v", err)
+		}
+		for _, item := range page.Items {
+			items = append(items, json.RawMessage(item))
+		}
+	}
+
+	return ContainerSnapshot{
+		partitionKeyField: strings.TrimPrefix(partitionKeyPath, "/"),
+		items:             items,
+	}, nil
+}
+
+// Restore deletes every item currently in containerClient and re-inserts
+// exactly the items captured by Snapshot, returning the container to the
+// state it was in when snapshot was taken.
+func (snapshot ContainerSnapshot) Restore(ctx context.Context, containerClient *azcosmos.ContainerClient) error {
+	current, err := Snapshot(ctx, containerClient)
+	if err != nil {
+		return err
+	}
+
+	for _, raw := range current.items {
+		id, partitionKeyValue, err := snapshot.itemIdentity(raw)
+		if err != nil {
+			return err
+		}
+		if _, err := containerClient.DeleteItem(ctx, azcosmos.NewPartitionKeyString(partitionKeyValue), id, nil); err != nil {
+			return fmt.Errorf("error deleting item %q while restoring snapshot: %v", id, err)
+		}
+	}
+
+	for _, raw := range snapshot.items {
+		_, partitionKeyValue, err := snapshot.itemIdentity(raw)
+		if err != nil {
+			return err
+		}
+		if _, err := containerClient.UpsertItem(ctx, azcosmos.NewPartitionKeyString(partitionKeyValue), raw, nil); err != nil {
+			return fmt.Errorf("error restoring item: %v", err)
+		}
+	}
+
+	return nil
+}
+
+func (snapshot ContainerSnapshot) itemIdentity(raw json.RawMessage) (id, partitionKeyValue string, err error) {
+	var item map[string]interface{}
+	if err := json.Unmarshal(raw, &item); err != nil {
+		return "", "", fmt.Errorf("error unmarshalling snapshotted item: %v", err)
+	}
+	id, _ = item["id"].(string)
+	partitionKeyValue, _ = item[snapshot.partitionKeyField].(string)
+	return id, partitionKeyValue, nil
+}
@@ -0,0 +1,25 @@
+package tools
+
+import (
+	"errors"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+)
+
+// FakeCosmosDBClientRetriever is an in-memory CosmosDBClientRetriever for unit
+// tests that don't have a Cosmos DB emulator available. Get always fails with
+// Err (or a generic error if Err is unset), which is enough to exercise
+// argument-validation and client-creation-error paths in tool handlers
+// without ever dialing out to Cosmos DB. Tests that need a real response
+// from the service belong behind the integration build tag instead, against
+// CosmosDBEmulatorClientRetriever in helper_test.go.
+type FakeCosmosDBClientRetriever struct {
+	Err error
+}
+
+func (retriever FakeCosmosDBClientRetriever) Get(accountName string) (*azcosmos.Client, error) {
+	if retriever.Err != nil {
+		return nil, retriever.Err
+	}
+	return nil, errors.New("FakeCosmosDBClientRetriever: no client configured for unit tests")
+}
@@ -1,11 +1,16 @@
+//go:build integration
+
 package tools
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -34,6 +39,10 @@ const (
 
 var (
 	emulator testcontainers.Container
+	// testHarness hands out a freshly created, uniquely-named container per
+	// test via testHarness.WithContainer, so tests that write items can run
+	// with t.Parallel() without stepping on each other's data.
+	testHarness *EmulatorHarness
 )
 
 // setupCosmosEmulator creates a CosmosDB emulator container for testing
@@ -94,6 +103,67 @@ func (retriever CosmosDBEmulatorClientRetriever) Get(account string) (*azcosmos.
 	return client, nil
 }
 
+// throttlingTransport simulates Cosmos DB throttling by returning a
+// synthetic 429 (with an x-ms-retry-after-ms header) for the first N SQL
+// query requests it sees, then forwarding everything else - including the
+// retried query - to the real emulator. Used to exercise ExecuteQuery's
+// retry-with-backoff handling without needing the emulator itself to be
+// under load.
+type throttlingTransport struct {
+	inner        http.RoundTripper
+	remaining    int32
+	retryAfterMs string
+}
+
+func (t *throttlingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Header.Get("x-ms-documentdb-isquery") == "True" && atomic.AddInt32(&t.remaining, -1) >= 0 {
+		body := io.NopCloser(bytes.NewReader([]byte(`{"code":"429","message":"Request rate is large"}`)))
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Status:     "429 Too Many Requests",
+			Proto:      "HTTP/1.1",
+			Header:     http.Header{"X-Ms-Retry-After-Ms": []string{t.retryAfterMs}},
+			Body:       body,
+			Request:    req,
+		}, nil
+	}
+	return t.inner.RoundTrip(req)
+}
+
+// throttledEmulatorClientRetriever is CosmosDBEmulatorClientRetriever with
+// its transport wrapped in throttlingTransport, so the first throttleCount
+// queries against it come back as 429s before succeeding.
+type throttledEmulatorClientRetriever struct {
+	throttleCount int32
+	retryAfterMs  string
+}
+
+func (retriever throttledEmulatorClientRetriever) Get(account string) (*azcosmos.Client, error) {
+	inner := &http.Transport{TLSClientConfig: &tls.Config{InsecureSkipVerify: true}}
+	httpClient := &http.Client{Transport: &throttlingTransport{
+		inner:        inner,
+		remaining:    retriever.throttleCount,
+		retryAfterMs: retriever.retryAfterMs,
+	}}
+
+	options := &azcosmos.ClientOptions{ClientOptions: azcore.ClientOptions{
+		TracingProvider: tracing.Provider{},
+		Transport:       httpClient,
+	}}
+
+	cred, err := azcosmos.NewKeyCredential(emulatorKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create key credential: %w", err)
+	}
+
+	client, err := azcosmos.NewClientWithKey(emulatorEndpoint, cred, options)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cosmos client: %w", err)
+	}
+
+	return client, nil
+}
+
 // setupDatabaseAndContainer ensures the test database and container exist
 func setupDatabaseAndContainer(ctx context.Context, client *azcosmos.Client) error {
 	// Try to create the test database
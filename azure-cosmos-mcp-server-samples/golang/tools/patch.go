@@ -0,0 +1,115 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const PATCH_ITEM_TOOL_NAME = "patch_item"
+
+func PatchItem(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
+	return patchItem(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		partitionKeyValue, ok := request.Params.Arguments["partitionKey"].(string)
+		if !ok || partitionKeyValue == "" {
+			return nil, errors.New("value for partition key missing")
+		}
+		id, ok := request.Params.Arguments["id"].(string)
+		if !ok || id == "" {
+			return nil, errors.New("item id missing")
+		}
+		operationsJSON, ok := request.Params.Arguments["operations"].(string)
+		if !ok || operationsJSON == "" {
+			return nil, errors.New("patch operations JSON array missing")
+		}
+
+		patchOperations, err := parsePatchOperations(json.RawMessage(operationsJSON))
+		if err != nil {
+			return nil, err
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, PATCH_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, id)
+		defer func() { endToolSpan(ctx, span, PATCH_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+
+		var itemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var patchErr error
+			itemResponse, patchErr = containerClient.PatchItem(ctx, partitionKey, id, patchOperations, nil)
+			return patchErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error patching item", attempts+1, retryErr)
+		}
+
+		requestCharge = itemResponse.RequestCharge
+
+		return mcp.NewToolResultText(string(itemResponse.Value)), nil
+	}
+}
+
+func patchItem() mcp.Tool {
+	return mcp.NewTool(PATCH_ITEM_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container holding the item"),
+		),
+		mcp.WithString("partitionKey",
+			mcp.Required(),
+			mcp.Description("Partition key of the item to patch"),
+		),
+		mcp.WithString("id",
+			mcp.Required(),
+			mcp.Description("ID of the item to patch"),
+		),
+		mcp.WithString("operations",
+			mcp.Required(),
+			mcp.Description("JSON array of patch operations to apply, e.g. [{\"op\":\"set\",\"path\":\"/status\",\"value\":\"done\"},{\"op\":\"incr\",\"path\":\"/count\",\"value\":1}]. Supported op values: set, add, remove, replace, incr."),
+		),
+		mcp.WithDescription("Apply one or more partial updates, each to one field, of an existing item without reading and rewriting the whole document. Useful for hot documents and counters."),
+	)
+}
@@ -5,15 +5,55 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/mark3labs/mcp-go/server"
 )
 
+type QueryParameter struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// maxAllowedRetryAttempts and maxAllowedRetryWaitMs bound the
+// maxRetryAttempts/maxRetryWaitMs arguments a caller can pass to ExecuteQuery,
+// so a very large requested value can't drive retryDelay's exponential
+// backoff calculation into overflow territory.
+const (
+	maxAllowedRetryAttempts = 20
+	maxAllowedRetryWaitMs   = 5 * 60 * 1000 // 5 minutes
+)
+
+type ExecuteQueryResponse struct {
+	Items             []json.RawMessage `json:"items"`
+	ContinuationToken string            `json:"continuationToken,omitempty"`
+	RequestCharge     float32           `json:"requestCharge"`
+	ActivityID        string            `json:"activityId,omitempty"`
+	// RetryCount and RetryDelayMs are only populated when the page fetch was
+	// throttled (429) or hit a transient failure (503) and had to be retried.
+	RetryCount   int   `json:"retryCount,omitempty"`
+	RetryDelayMs int64 `json:"retryDelayMs,omitempty"`
+}
+
+// ExecuteQuery runs a query and returns exactly one page of results, along
+// with a continuation token, the RU charge and the activity id for that
+// page, so large result sets - including cross-partition ones - can be paged
+// through deterministically across repeated tool calls instead of being
+// buffered entirely in memory. Paging via maxItemCount/continuationToken is
+// orthogonal to which query clauses are supported: TOP/ORDER BY/OFFSET
+// LIMIT/GROUP BY remain unsupported because of the SDK's query engine, not
+// because of how pages are fetched.
+//
+// This supersedes the standalone execute_query_page tool: its paging,
+// continuation-token and RU-accounting behavior was folded in here rather
+// than kept as a second tool, so callers have exactly one query entry point
+// instead of two with overlapping semantics. execute_query_page is
+// intentionally removed, not just missing.
 func ExecuteQuery(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
 
-	return execute_query(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return execute_query(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
@@ -33,6 +73,51 @@ func ExecuteQuery(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.Too
 		}
 
 		partitionKeyValue, hasPartitionKey := request.Params.Arguments["partitionKey"].(string)
+		enableCrossPartition, _ := request.Params.Arguments["enableCrossPartition"].(bool)
+		if hasPartitionKey && enableCrossPartition {
+			return nil, errors.New("partitionKey and enableCrossPartition are mutually exclusive")
+		}
+		if !hasPartitionKey && !enableCrossPartition {
+			return nil, errors.New("either partitionKey or enableCrossPartition must be provided")
+		}
+
+		continuationToken, _ := request.Params.Arguments["continuationToken"].(string)
+
+		var maxItemCount int
+		if raw, ok := request.Params.Arguments["maxItemCount"].(float64); ok {
+			maxItemCount = int(raw)
+		}
+
+		var queryParameters []azcosmos.QueryParameter
+		if parametersJSON, ok := request.Params.Arguments["parameters"].(string); ok && parametersJSON != "" {
+			var parameters []QueryParameter
+			if err := json.Unmarshal([]byte(parametersJSON), &parameters); err != nil {
+				return nil, fmt.Errorf("error unmarshalling parameters JSON: %v", err)
+			}
+			for _, parameter := range parameters {
+				queryParameters = append(queryParameters, azcosmos.QueryParameter{Name: parameter.Name, Value: parameter.Value})
+			}
+		}
+
+		retryPolicy := RetryPolicyFromEnv()
+		if raw, ok := request.Params.Arguments["maxRetryAttempts"].(float64); ok {
+			attempts := int(raw)
+			if attempts > maxAllowedRetryAttempts {
+				attempts = maxAllowedRetryAttempts
+			}
+			retryPolicy.MaxAttempts = attempts
+		}
+		if raw, ok := request.Params.Arguments["maxRetryWaitMs"].(float64); ok {
+			waitMs := raw
+			if waitMs > maxAllowedRetryWaitMs {
+				waitMs = maxAllowedRetryWaitMs
+			}
+			retryPolicy.MaxTotalWait = time.Duration(waitMs) * time.Millisecond
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, EXECUTE_QUERY_TOOL_NAME, account, database, container, partitionKeyValue, "")
+		defer func() { endToolSpan(ctx, span, EXECUTE_QUERY_TOOL_NAME, start, requestCharge, 0, err) }()
 
 		client, err := clientRetriever.Get(account)
 
@@ -58,27 +143,43 @@ func ExecuteQuery(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.Too
 			partitionKey = azcosmos.PartitionKey{} // Empty partition key for cross-partition queries
 		}
 
-		queryPager := containerClient.NewQueryItemsPager(query, partitionKey, nil)
+		queryOptions := &azcosmos.QueryOptions{QueryParameters: queryParameters}
+		if maxItemCount > 0 {
+			queryOptions.PageSizeHint = int32(maxItemCount)
+		}
+		if continuationToken != "" {
+			queryOptions.ContinuationToken = &continuationToken
+		}
+
+		queryPager := containerClient.NewQueryItemsPager(query, partitionKey, queryOptions)
+
+		response := ExecuteQueryResponse{Items: []json.RawMessage{}}
 
-		var response ExecuteQueryResponse
+		if queryPager.More() {
+			var queryResponse azcosmos.QueryItemsResponse
 
-		for queryPager.More() {
-			queryResponse, err := queryPager.NextPage(context.Background())
-			if err != nil {
-				return nil, fmt.Errorf("query page error: %v", err)
+			retryCount, totalRetryDelay, retryErr := retryOnThrottle(ctx, retryPolicy, func() error {
+				var pageErr error
+				queryResponse, pageErr = queryPager.NextPage(ctx)
+				return pageErr
+			})
+			if retryErr != nil {
+				return nil, wrapRetryExhausted("query page error", retryCount+1, retryErr)
 			}
+			requestCharge = queryResponse.RequestCharge
 
-			// Store items directly as json.RawMessage
 			for _, item := range queryResponse.Items {
-				response.QueryResults = append(response.QueryResults, json.RawMessage(item))
+				response.Items = append(response.Items, json.RawMessage(item))
+			}
+			response.RequestCharge = queryResponse.RequestCharge
+			response.ActivityID = queryResponse.ActivityID
+			response.RetryCount = retryCount
+			response.RetryDelayMs = totalRetryDelay.Milliseconds()
+			if queryResponse.ContinuationToken != nil {
+				response.ContinuationToken = *queryResponse.ContinuationToken
 			}
-
-			response.QueryMetrics = append(response.QueryMetrics, *queryResponse.QueryMetrics)
 		}
 
-		// Set metrics information
-
-		// Marshal the entire response struct
 		jsonResult, err := json.Marshal(response)
 		if err != nil {
 			return nil, fmt.Errorf("json marshal error: %v", err)
@@ -88,11 +189,6 @@ func ExecuteQuery(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.Too
 	}
 }
 
-type ExecuteQueryResponse struct {
-	QueryResults []json.RawMessage `json:"results"`
-	QueryMetrics []string          `json:"metrics"`
-}
-
 func execute_query() mcp.Tool {
 
 	return mcp.NewTool(EXECUTE_QUERY_TOOL_NAME,
@@ -110,18 +206,36 @@ func execute_query() mcp.Tool {
 		),
 		mcp.WithString("query",
 			mcp.Required(),
-			mcp.Description("The SQL query string to execute."),
+			mcp.Description("The SQL query string to execute. Use @paramName placeholders together with the parameters argument for parameterized queries."),
 		),
 		mcp.WithString("partitionKey",
-			mcp.Description("The partition key value for the query. If provided, the query will be scoped to this partition."),
+			mcp.Description("The partition key value to scope the query to. Either this or enableCrossPartition must be set."),
+		),
+		mcp.WithBoolean("enableCrossPartition",
+			mcp.Description("Set to true to run the query across all partitions. Either this or partitionKey must be set."),
+		),
+		mcp.WithNumber("maxItemCount",
+			mcp.Description("Maximum number of items to return in this page (optional, the service may return fewer)"),
+		),
+		mcp.WithString("continuationToken",
+			mcp.Description("Opaque continuation token returned by a previous call, used to fetch the next page (omit to fetch the first page)"),
+		),
+		mcp.WithString("parameters",
+			mcp.Description("JSON array of query parameters, e.g. [{\"name\":\"@id\",\"value\":\"abc\"}], bound to @param placeholders in the query"),
 		),
-		mcp.WithDescription("Execute a general query on a Cosmos DB container. If the query fails with an error related to cross partition query, do not ask the user to provide a partition key. Instead, try a different query that does not require a partition key. Do not use the `TOP`, `ORDER BY`, `OFFSET LIMIT`, `DISTINCT` and `GROUP BY` clauses in the query as they are not supported by the SDK used to implement this tool. Simple projections and Filters are supported in the query. Ensure that the query string is valid and adheres to Cosmos DB SQL syntax. To use a partition key in the query directly, add it in the WHERE clause. Example: SELECT * FROM c WHERE c.department='HR'."),
+		mcp.WithNumber("maxRetryAttempts",
+			mcp.Description(fmt.Sprintf("Maximum number of attempts (including the first) when the page fetch is throttled (429) or hits a transient failure (503). Defaults to COSMOS_QUERY_MAX_RETRY_ATTEMPTS or 5, capped at %d.", maxAllowedRetryAttempts)),
+		),
+		mcp.WithNumber("maxRetryWaitMs",
+			mcp.Description(fmt.Sprintf("Give up retrying once the cumulative retry delay would exceed this many milliseconds. Defaults to COSMOS_QUERY_MAX_RETRY_WAIT_MS or 30000, capped at %d.", maxAllowedRetryWaitMs)),
+		),
+		mcp.WithDescription("Execute a query and return exactly one page of results along with a continuation token, RU charge and activity id for the page, so large result sets - including ones spanning every partition when enableCrossPartition is set - can be paged through deterministically across repeated tool calls. Throttled (429) and transient (503) responses are retried automatically, honoring the server's x-ms-retry-after-ms header; the response reports how many retries were needed. Do not use the `TOP`, `ORDER BY`, `OFFSET LIMIT`, `DISTINCT` and `GROUP BY` clauses in the query: paging via continuationToken does not change this, as the restriction comes from the SDK's query engine itself, not from how results are paged. Simple projections and filters are supported. To scope the query to a partition, prefer the partitionKey argument over a WHERE clause."),
 	)
 }
 
 func ReadItem(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHandlerFunc) {
 
-	return readItem(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+	return readItem(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
 
 		account, ok := request.Params.Arguments["account"].(string)
 		if !ok || account == "" {
@@ -144,6 +258,10 @@ func ReadItem(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHan
 			return nil, errors.New("partition key missing")
 		}
 
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, READ_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, itemID)
+		defer func() { endToolSpan(ctx, span, READ_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
+
 		client, err := clientRetriever.Get(account)
 
 		if err != nil {
@@ -162,10 +280,17 @@ func ReadItem(clientRetriever CosmosDBClientRetriever) (mcp.Tool, server.ToolHan
 		}
 
 		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
-		itemResponse, err := containerClient.ReadItem(ctx, partitionKey, itemID, nil)
-		if err != nil {
-			return nil, fmt.Errorf("error reading item: %v", err)
+
+		var itemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var readErr error
+			itemResponse, readErr = containerClient.ReadItem(ctx, partitionKey, itemID, nil)
+			return readErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error reading item", attempts+1, retryErr)
 		}
+		requestCharge = itemResponse.RequestCharge
 
 		// var item map[string]interface{}
 		// if err := json.Unmarshal(itemResponse.Value, &item); err != nil {
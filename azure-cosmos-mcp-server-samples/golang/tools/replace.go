@@ -0,0 +1,131 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const REPLACE_ITEM_TOOL_NAME = "replace_item"
+
+func ReplaceItem(clientRetriever CosmosDBClientRetriever, schemaRegistry *SchemaRegistry) (mcp.Tool, server.ToolHandlerFunc) {
+	return replaceItem(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		itemID, ok := request.Params.Arguments["itemID"].(string)
+		if !ok || itemID == "" {
+			return nil, errors.New("item ID missing")
+		}
+		partitionKeyValue, ok := request.Params.Arguments["partitionKey"].(string)
+		if !ok || partitionKeyValue == "" {
+			return nil, errors.New("value for partition key missing")
+		}
+		itemJSON, ok := request.Params.Arguments["item"].(string)
+		if !ok || itemJSON == "" {
+			return nil, errors.New("item JSON missing")
+		}
+
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
+			return nil, fmt.Errorf("error unmarshalling item JSON: %v", err)
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, REPLACE_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, itemID)
+		defer func() { endToolSpan(ctx, span, REPLACE_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		partitionKeyPath, err := fetchPartitionKeyPath(ctx, containerClient)
+		if err != nil {
+			return nil, fmt.Errorf("error reading container metadata: %v", err)
+		}
+
+		violations := validateItemIdentity(item, partitionKeyPath)
+		if schema, ok := schemaRegistry.Get(account, database, container); ok {
+			violations = append(violations, validateItemAgainstSchema(item, schema)...)
+		}
+		if len(violations) > 0 {
+			jsonResult, err := json.Marshal(ItemValidationResponse{Valid: false, Violations: violations})
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling validation result to JSON: %v", err)
+			}
+			return mcp.NewToolResultText(string(jsonResult)), nil
+		}
+
+		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+
+		var itemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var replaceErr error
+			itemResponse, replaceErr = containerClient.ReplaceItem(ctx, partitionKey, itemID, []byte(itemJSON), nil)
+			return replaceErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error replacing item", attempts+1, retryErr)
+		}
+		requestCharge = itemResponse.RequestCharge
+
+		return mcp.NewToolResultText(string(itemResponse.Value)), nil
+	}
+}
+
+func replaceItem() mcp.Tool {
+	return mcp.NewTool(REPLACE_ITEM_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container holding the item"),
+		),
+		mcp.WithString("itemID",
+			mcp.Required(),
+			mcp.Description("ID of the item to replace"),
+		),
+		mcp.WithString("partitionKey",
+			mcp.Required(),
+			mcp.Description("Partition key of the item to replace"),
+		),
+		mcp.WithString("item",
+			mcp.Required(),
+			mcp.Description("The full JSON representation of the replacement item. id field is mandatory"),
+		),
+		mcp.WithDescription("Replace an existing item in a container with a new version. Unlike patch_item, this replaces the whole document."),
+	)
+}
@@ -0,0 +1,161 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+	"net/http"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+)
+
+// RetryPolicy controls how transient Cosmos DB failures (429 Too Many
+// Requests, 503 Service Unavailable) are retried. When the service reports
+// a delay via the x-ms-retry-after-ms header, that value is honored as-is;
+// otherwise delays grow exponentially with full jitter.
+type RetryPolicy struct {
+	// MaxAttempts is the total number of tries, including the first. Values
+	// <= 0 fall back to DefaultRetryPolicy.MaxAttempts.
+	MaxAttempts int
+	// MaxTotalWait caps the cumulative delay slept across all retries for a
+	// single call. Zero means no cap.
+	MaxTotalWait time.Duration
+	// BaseDelay is the starting backoff used when the server doesn't report
+	// x-ms-retry-after-ms. Values <= 0 fall back to DefaultRetryPolicy.BaseDelay.
+	BaseDelay time.Duration
+}
+
+// DefaultRetryPolicy is used when a tool call doesn't override retry
+// behavior via arguments and no env defaults are configured.
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:  5,
+	MaxTotalWait: 30 * time.Second,
+	BaseDelay:    100 * time.Millisecond,
+}
+
+// maxBackoffDelay caps the exponential backoff retryDelay computes before
+// jitter is applied. Without a real ceiling, a large enough attempt count
+// overflows time.Duration's underlying int64 nanoseconds and wraps negative,
+// which panics in rand.Int63n.
+const maxBackoffDelay = 30 * time.Second
+
+// RetryPolicyFromEnv builds a RetryPolicy from COSMOS_QUERY_MAX_RETRY_ATTEMPTS
+// and COSMOS_QUERY_MAX_RETRY_WAIT_MS, falling back to DefaultRetryPolicy for
+// any value that's unset or invalid.
+func RetryPolicyFromEnv() RetryPolicy {
+	policy := DefaultRetryPolicy
+	if attempts, err := strconv.Atoi(os.Getenv("COSMOS_QUERY_MAX_RETRY_ATTEMPTS")); err == nil && attempts > 0 {
+		policy.MaxAttempts = attempts
+	}
+	if waitMs, err := strconv.Atoi(os.Getenv("COSMOS_QUERY_MAX_RETRY_WAIT_MS")); err == nil && waitMs > 0 {
+		policy.MaxTotalWait = time.Duration(waitMs) * time.Millisecond
+	}
+	return policy
+}
+
+// isRetryableResponseError reports whether err is a throttling (429) or
+// transient (503) response from Cosmos DB.
+func isRetryableResponseError(err error) bool {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) {
+		return false
+	}
+	return responseErr.StatusCode == http.StatusTooManyRequests || responseErr.StatusCode == http.StatusServiceUnavailable
+}
+
+// retryDelay extracts the server-requested delay from the
+// x-ms-retry-after-ms header on a throttled/unavailable response, falling
+// back to exponential backoff with full jitter when the header is absent or
+// unparseable.
+func retryDelay(err error, attempt int, baseDelay time.Duration) time.Duration {
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) && responseErr.RawResponse != nil {
+		if raw := responseErr.RawResponse.Header.Get("x-ms-retry-after-ms"); raw != "" {
+			if ms, parseErr := strconv.Atoi(raw); parseErr == nil {
+				return time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	capped := baseDelay * time.Duration(math.Pow(2, float64(attempt)))
+	if capped <= 0 || capped > maxBackoffDelay {
+		capped = maxBackoffDelay
+	}
+	return time.Duration(rand.Int63n(int64(capped) + 1))
+}
+
+// retryOnThrottle calls fn up to policy.MaxAttempts times, retrying whenever
+// fn returns a throttling (429) or transient (503) response error. Between
+// attempts it sleeps the delay reported via the x-ms-retry-after-ms header
+// (falling back to exponential backoff with full jitter), stopping early
+// once the cumulative delay would exceed policy.MaxTotalWait. It returns how
+// many retries were performed, the cumulative delay slept, and fn's final
+// error (nil on success).
+func retryOnThrottle(ctx context.Context, policy RetryPolicy, fn func() error) (retries int, totalDelay time.Duration, err error) {
+	maxAttempts := policy.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryPolicy.MaxAttempts
+	}
+	baseDelay := policy.BaseDelay
+	if baseDelay <= 0 {
+		baseDelay = DefaultRetryPolicy.BaseDelay
+	}
+
+	for attempt := 0; ; attempt++ {
+		err = fn()
+		if err == nil || !isRetryableResponseError(err) || attempt == maxAttempts-1 {
+			return retries, totalDelay, err
+		}
+
+		delay := retryDelay(err, attempt, baseDelay)
+		if policy.MaxTotalWait > 0 && totalDelay+delay > policy.MaxTotalWait {
+			return retries, totalDelay, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return retries, totalDelay, ctx.Err()
+		case <-time.After(delay):
+		}
+
+		retries++
+		totalDelay += delay
+	}
+}
+
+// retryAfterFromError extracts the server-requested delay from the
+// x-ms-retry-after-ms header on a throttled/unavailable response, if present.
+func retryAfterFromError(err error) (time.Duration, bool) {
+	var responseErr *azcore.ResponseError
+	if !errors.As(err, &responseErr) || responseErr.RawResponse == nil {
+		return 0, false
+	}
+	raw := responseErr.RawResponse.Header.Get("x-ms-retry-after-ms")
+	if raw == "" {
+		return 0, false
+	}
+	ms, parseErr := strconv.Atoi(raw)
+	if parseErr != nil {
+		return 0, false
+	}
+	return time.Duration(ms) * time.Millisecond, true
+}
+
+// wrapRetryExhausted builds an error for operation after retryOnThrottle gave
+// up, reporting how many attempts were made and, when available, the last
+// retry-after delay the service requested, so the MCP caller can decide
+// whether to back off further or surface the failure to the user.
+func wrapRetryExhausted(operation string, attempts int, err error) error {
+	if !isRetryableResponseError(err) {
+		return fmt.Errorf("%s: %v", operation, err)
+	}
+	if retryAfter, ok := retryAfterFromError(err); ok {
+		return fmt.Errorf("%s: exhausted %d attempt(s) against Cosmos DB, last retry-after %v: %w", operation, attempts, retryAfter, err)
+	}
+	return fmt.Errorf("%s: exhausted %d attempt(s) against Cosmos DB: %w", operation, attempts, err)
+}
@@ -0,0 +1,104 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func throttledResponseError(retryAfterMs string) *azcore.ResponseError {
+	header := http.Header{}
+	if retryAfterMs != "" {
+		header.Set("x-ms-retry-after-ms", retryAfterMs)
+	}
+	return &azcore.ResponseError{
+		StatusCode:  http.StatusTooManyRequests,
+		RawResponse: &http.Response{Header: header},
+	}
+}
+
+func TestIsRetryableResponseErrorUnit(t *testing.T) {
+	assert.True(t, isRetryableResponseError(throttledResponseError("")))
+	assert.True(t, isRetryableResponseError(&azcore.ResponseError{StatusCode: http.StatusServiceUnavailable}))
+	assert.False(t, isRetryableResponseError(&azcore.ResponseError{StatusCode: http.StatusBadRequest}))
+	assert.False(t, isRetryableResponseError(errors.New("not a response error")))
+}
+
+func TestRetryDelayUnit(t *testing.T) {
+	delay := retryDelay(throttledResponseError("250"), 0, 100*time.Millisecond)
+	assert.Equal(t, 250*time.Millisecond, delay)
+
+	// No header: falls back to bounded exponential backoff with jitter.
+	delay = retryDelay(throttledResponseError(""), 2, 100*time.Millisecond)
+	assert.GreaterOrEqual(t, delay, time.Duration(0))
+	assert.LessOrEqual(t, delay, 400*time.Millisecond)
+}
+
+func TestRetryDelayCapsLargeAttemptsUnit(t *testing.T) {
+	// With a 100ms base, baseDelay * 2^attempt first overflows
+	// time.Duration's int64 nanoseconds at attempt 37; without a real ceiling this used to wrap
+	// negative and panic inside rand.Int63n.
+	for _, attempt := range []int{37, 62, 1000} {
+		delay := retryDelay(throttledResponseError(""), attempt, 100*time.Millisecond)
+		assert.GreaterOrEqual(t, delay, time.Duration(0))
+		assert.LessOrEqual(t, delay, maxBackoffDelay)
+	}
+}
+
+func TestRetryOnThrottleUnit(t *testing.T) {
+	attempts := 0
+	retries, _, err := retryOnThrottle(context.Background(), RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		if attempts < 3 {
+			return throttledResponseError("1")
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, 2, retries)
+	assert.Equal(t, 3, attempts)
+
+	attempts = 0
+	_, _, err = retryOnThrottle(context.Background(), RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return throttledResponseError("1")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 2, attempts)
+
+	// Non-retryable errors fail fast without retrying.
+	attempts = 0
+	_, _, err = retryOnThrottle(context.Background(), RetryPolicy{MaxAttempts: 5, BaseDelay: time.Millisecond}, func() error {
+		attempts++
+		return errors.New("permanent failure")
+	})
+	require.Error(t, err)
+	assert.Equal(t, 1, attempts)
+}
+
+func TestWrapRetryExhaustedUnit(t *testing.T) {
+	err := wrapRetryExhausted("error reading item", 5, throttledResponseError("250"))
+	assert.Contains(t, err.Error(), "error reading item")
+	assert.Contains(t, err.Error(), "5 attempt(s)")
+	assert.Contains(t, err.Error(), "250ms")
+	assert.True(t, isRetryableResponseError(errors.Unwrap(err)))
+
+	plain := errors.New("not found")
+	err = wrapRetryExhausted("error reading item", 1, plain)
+	assert.Equal(t, "error reading item: not found", err.Error())
+}
+
+func TestRetryPolicyFromEnvUnit(t *testing.T) {
+	t.Setenv("COSMOS_QUERY_MAX_RETRY_ATTEMPTS", "7")
+	t.Setenv("COSMOS_QUERY_MAX_RETRY_WAIT_MS", "2000")
+
+	policy := RetryPolicyFromEnv()
+	assert.Equal(t, 7, policy.MaxAttempts)
+	assert.Equal(t, 2*time.Second, policy.MaxTotalWait)
+}
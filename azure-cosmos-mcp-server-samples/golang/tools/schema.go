@@ -0,0 +1,210 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const REGISTER_ITEM_SCHEMA_TOOL_NAME = "register_item_schema"
+
+// ItemSchema declares the expected JSON type ("null", "boolean", "number",
+// "string", "object" or "array") of each top-level field of an item, and
+// which of those fields are required. It is intentionally not a full JSON
+// Schema implementation - just enough to catch the shape mistakes that
+// otherwise surface as opaque Cosmos DB errors.
+type ItemSchema struct {
+	Properties map[string]string `json:"properties"`
+	Required   []string          `json:"required"`
+}
+
+// SchemaRegistry holds one ItemSchema per (account, database, container),
+// registered via RegisterItemSchema and consulted by AddItemToContainer,
+// ReplaceItem and UpsertItem before an item is sent to Cosmos DB. Safe for concurrent use.
+type SchemaRegistry struct {
+	mu      sync.RWMutex
+	schemas map[string]ItemSchema
+}
+
+// NewSchemaRegistry returns an empty SchemaRegistry, ready to use.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{schemas: map[string]ItemSchema{}}
+}
+
+func schemaKey(account, database, container string) string {
+	return account + "/" + database + "/" + container
+}
+
+// Register stores schema for the given (account, database, container),
+// replacing any schema previously registered for the same coordinates.
+func (registry *SchemaRegistry) Register(account, database, container string, schema ItemSchema) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	registry.schemas[schemaKey(account, database, container)] = schema
+}
+
+// Get returns the schema registered for the given (account, database,
+// container), if any.
+func (registry *SchemaRegistry) Get(account, database, container string) (ItemSchema, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	schema, ok := registry.schemas[schemaKey(account, database, container)]
+	return schema, ok
+}
+
+// schemaViolation describes a single mismatch between an item and the rules
+// checked against it - either a declared ItemSchema or the built-in id/
+// partition-key checks - identified by JSON pointer path.
+type schemaViolation struct {
+	Path         string `json:"path"`
+	ExpectedType string `json:"expectedType"`
+	ActualType   string `json:"actualType"`
+}
+
+// jsonType maps a value decoded from encoding/json (via map[string]interface{})
+// to its JSON Schema type name.
+func jsonType(value interface{}) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	default:
+		return fmt.Sprintf("%T", value)
+	}
+}
+
+// validateItemIdentity enforces that id is present and is a string, and
+// that, when partitionKeyPath is a top-level field (e.g. "/department"),
+// its value is present and non-empty. Catches the two failure modes that
+// otherwise only surface as opaque errors from the Cosmos DB SDK.
+func validateItemIdentity(item map[string]interface{}, partitionKeyPath string) []schemaViolation {
+	var violations []schemaViolation
+
+	idValue, ok := item["id"]
+	switch {
+	case !ok:
+		violations = append(violations, schemaViolation{Path: "/id", ExpectedType: "string", ActualType: "missing"})
+	case jsonType(idValue) != "string":
+		violations = append(violations, schemaViolation{Path: "/id", ExpectedType: "string", ActualType: jsonType(idValue)})
+	}
+
+	fieldName := strings.TrimPrefix(partitionKeyPath, "/")
+	if fieldName == "" || strings.Contains(fieldName, "/") {
+		// Nested partition keys aren't inspected - only single top-level fields.
+		return violations
+	}
+
+	partitionKeyValue, ok := item[fieldName]
+	switch {
+	case !ok:
+		violations = append(violations, schemaViolation{Path: partitionKeyPath, ExpectedType: "non-empty value", ActualType: "missing"})
+	case partitionKeyValue == "":
+		violations = append(violations, schemaViolation{Path: partitionKeyPath, ExpectedType: "non-empty value", ActualType: "empty string"})
+	}
+
+	return violations
+}
+
+// validateItemAgainstSchema compares item's top-level fields against schema,
+// reporting a violation for every required field that's missing and every
+// present field whose actual type doesn't match the declared one.
+func validateItemAgainstSchema(item map[string]interface{}, schema ItemSchema) []schemaViolation {
+	var violations []schemaViolation
+
+	for _, requiredField := range schema.Required {
+		if _, ok := item[requiredField]; !ok {
+			violations = append(violations, schemaViolation{
+				Path:         "/" + requiredField,
+				ExpectedType: schema.Properties[requiredField],
+				ActualType:   "missing",
+			})
+		}
+	}
+
+	for field, expectedType := range schema.Properties {
+		value, ok := item[field]
+		if !ok {
+			continue
+		}
+		if actualType := jsonType(value); actualType != expectedType {
+			violations = append(violations, schemaViolation{Path: "/" + field, ExpectedType: expectedType, ActualType: actualType})
+		}
+	}
+
+	return violations
+}
+
+// ItemValidationResponse reports whether an item passed validation, and if
+// not, every offending field.
+type ItemValidationResponse struct {
+	Valid      bool              `json:"valid"`
+	Violations []schemaViolation `json:"violations,omitempty"`
+}
+
+func RegisterItemSchema(registry *SchemaRegistry) (mcp.Tool, server.ToolHandlerFunc) {
+	return registerItemSchema(), func(ctx context.Context, request mcp.CallToolRequest) (*mcp.CallToolResult, error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		schemaJSON, ok := request.Params.Arguments["schema"].(string)
+		if !ok || schemaJSON == "" {
+			return nil, errors.New("schema JSON missing")
+		}
+
+		var schema ItemSchema
+		if err := json.Unmarshal([]byte(schemaJSON), &schema); err != nil {
+			return nil, fmt.Errorf("error unmarshalling schema JSON: %v", err)
+		}
+
+		registry.Register(account, database, container, schema)
+
+		return mcp.NewToolResultText(fmt.Sprintf("Item schema registered for %s/%s/%s", account, database, container)), nil
+	}
+}
+
+func registerItemSchema() mcp.Tool {
+	return mcp.NewTool(REGISTER_ITEM_SCHEMA_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container the schema applies to"),
+		),
+		mcp.WithString("schema",
+			mcp.Required(),
+			mcp.Description("JSON object with \"properties\" (map of field name to expected JSON type: null, boolean, number, string, object or array) and \"required\" (list of field names), e.g. {\"properties\":{\"status\":\"string\"},\"required\":[\"status\"]}"),
+		),
+		mcp.WithDescription("Register a lightweight item schema for a container, used by add_item_to_container, replace_item and upsert_item to validate items before sending them to Cosmos DB."),
+	)
+}
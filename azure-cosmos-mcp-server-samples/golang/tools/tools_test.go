@@ -1,3 +1,11 @@
+//go:build integration
+
+// These tests exercise a live Cosmos DB emulator via
+// CosmosDBEmulatorClientRetriever and are gated behind the "integration"
+// build tag so `go test ./...` stays green on machines without Docker. Run
+// them with `go test -tags integration ./...` (or `make integration-test`).
+// For tool metadata and argument-validation coverage that runs everywhere,
+// see tools_unit_test.go.
 package tools
 
 import (
@@ -7,6 +15,7 @@ import (
 	"os"
 	"testing"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
 	"github.com/mark3labs/mcp-go/mcp"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -383,7 +392,14 @@ func TestCreateContainer(t *testing.T) {
 }
 
 func TestAddItemToContainer(t *testing.T) {
-	tool, handler := AddItemToContainer(CosmosDBEmulatorClientRetriever{})
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testAddItemToContainer(t, containerClient.ID())
+	})
+}
+
+func testAddItemToContainer(t *testing.T, testOperationContainerName string) {
+	tool, handler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
 
 	assert.Equal(t, tool.Name, ADD_CONTAINER_ITEM_TOOL_NAME)
 	assert.NotEmpty(t, tool.Description)
@@ -434,8 +450,10 @@ func TestAddItemToContainer(t *testing.T) {
 				"partitionKey": "1",
 				"item":         `{"value": "testValue"}`,
 			},
-			expectError:    true,
-			expectedErrMsg: "error adding item to container",
+			// Caught by item validation before the call reaches Cosmos DB: the
+			// handler returns a structured validation result, not an error.
+			expectError:    false,
+			expectedResult: `{"valid":false,"violations":[{"path":"/id","expectedType":"string","actualType":"missing"},{"path":"/id","expectedType":"non-empty value","actualType":"missing"}]}`,
 		},
 		{
 			name: "empty account name",
@@ -529,6 +547,13 @@ func TestAddItemToContainer(t *testing.T) {
 }
 
 func TestReadItem(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testReadItem(t, containerClient.ID())
+	})
+}
+
+func testReadItem(t *testing.T, testOperationContainerName string) {
 	tool, handler := ReadItem(CosmosDBEmulatorClientRetriever{})
 
 	assert.Equal(t, tool.Name, READ_ITEM_TOOL_NAME)
@@ -543,7 +568,7 @@ func TestReadItem(t *testing.T) {
 	id := "user2"
 	partitionKeyValue := "user2"
 
-	tool, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{})
+	tool, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
 
 	// need to add an item to the container first
 	_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
@@ -680,7 +705,263 @@ func TestReadItem(t *testing.T) {
 	}
 }
 
+func TestUpsertItem(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testUpsertItem(t, containerClient.ID())
+	})
+}
+
+func testUpsertItem(t *testing.T, testOperationContainerName string) {
+	tool, handler := UpsertItem(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+
+	assert.Equal(t, tool.Name, UPSERT_ITEM_TOOL_NAME)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "account")
+	assert.Contains(t, tool.InputSchema.Properties, "database")
+	assert.Contains(t, tool.InputSchema.Properties, "container")
+	assert.Contains(t, tool.InputSchema.Properties, "partitionKey")
+	assert.Contains(t, tool.InputSchema.Properties, "item")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"account", "database", "container", "partitionKey", "item"})
+
+	tests := []struct {
+		name           string
+		arguments      map[string]interface{}
+		expectError    bool
+		expectedErrMsg string
+	}{
+		{
+			name: "valid arguments - item does not exist yet",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "upsertUser1",
+				"item":         `{"id": "upsertUser1", "value": "first"}`,
+			},
+			expectError: false,
+		},
+		{
+			name: "valid arguments - item already exists",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "upsertUser1",
+				"item":         `{"id": "upsertUser1", "value": "second"}`,
+			},
+			expectError: false,
+		},
+		{
+			name: "missing id attribute",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "upsertUser2",
+				"item":         `{"value": "noID"}`,
+			},
+			expectError: false,
+		},
+		{
+			name: "empty account name",
+			arguments: map[string]interface{}{
+				"account":      "",
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "testPartitionKey",
+				"item":         `{"id": "testItem", "value": "testValue"}`,
+			},
+			expectError:    true,
+			expectedErrMsg: "cosmos db account name missing",
+		},
+		{
+			name: "empty partition key",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "",
+				"item":         `{"id": "testItem", "value": "testValue"}`,
+			},
+			expectError:    true,
+			expectedErrMsg: "value for partition key missing",
+		},
+		{
+			name: "empty item",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": "testPartitionKey",
+				"item":         "",
+			},
+			expectError:    true,
+			expectedErrMsg: "item JSON missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{
+				Params: struct {
+					Name      string                 `json:"name"`
+					Arguments map[string]interface{} `json:"arguments,omitempty"`
+					Meta      *struct {
+						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+					} `json:"_meta,omitempty"`
+				}{
+					Arguments: test.arguments,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+			if test.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.NotEmpty(t, getTextFromToolResult(t, result))
+		})
+	}
+}
+
+func TestDeleteItem(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testDeleteItem(t, containerClient.ID())
+	})
+}
+
+func testDeleteItem(t *testing.T, testOperationContainerName string) {
+	tool, handler := DeleteItem(CosmosDBEmulatorClientRetriever{})
+
+	assert.Equal(t, tool.Name, DELETE_ITEM_TOOL_NAME)
+	assert.NotEmpty(t, tool.Description)
+	assert.Contains(t, tool.InputSchema.Properties, "account")
+	assert.Contains(t, tool.InputSchema.Properties, "database")
+	assert.Contains(t, tool.InputSchema.Properties, "container")
+	assert.Contains(t, tool.InputSchema.Properties, "itemID")
+	assert.Contains(t, tool.InputSchema.Properties, "partitionKey")
+	assert.ElementsMatch(t, tool.InputSchema.Required, []string{"account", "database", "container", "itemID", "partitionKey"})
+
+	id := "deleteUser1"
+	partitionKeyValue := "deleteUser1"
+
+	_, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+	_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": partitionKeyValue,
+				"item":         fmt.Sprintf(`{"id": "%s", "value": "toBeDeleted"}`, id),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name           string
+		arguments      map[string]interface{}
+		expectError    bool
+		expectedResult string
+		expectedErrMsg string
+	}{
+		{
+			name: "valid arguments",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"itemID":       id,
+				"partitionKey": partitionKeyValue,
+			},
+			expectedResult: fmt.Sprintf("Item '%s' deleted successfully from container '%s' in database '%s'", id, testOperationContainerName, testOperationDBName),
+			expectError:    false,
+		},
+		{
+			name: "item no longer exists",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"itemID":       id,
+				"partitionKey": partitionKeyValue,
+			},
+			expectError:    true,
+			expectedErrMsg: "error deleting item",
+		},
+		{
+			name: "empty account name",
+			arguments: map[string]interface{}{
+				"account":      "",
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"itemID":       "testItem",
+				"partitionKey": "testPartitionKey",
+			},
+			expectError:    true,
+			expectedErrMsg: "cosmos db account name missing",
+		},
+		{
+			name: "empty item ID",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"itemID":       "",
+				"partitionKey": "testPartitionKey",
+			},
+			expectError:    true,
+			expectedErrMsg: "item ID missing",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := mcp.CallToolRequest{
+				Params: struct {
+					Name      string                 `json:"name"`
+					Arguments map[string]interface{} `json:"arguments,omitempty"`
+					Meta      *struct {
+						ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+					} `json:"_meta,omitempty"`
+				}{
+					Arguments: test.arguments,
+				},
+			}
+
+			result, err := handler(context.Background(), req)
+			if test.expectError {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), test.expectedErrMsg)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, test.expectedResult, getTextFromToolResult(t, result))
+		})
+	}
+}
+
 func TestExecuteQuery(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testExecuteQuery(t, containerClient.ID())
+	})
+}
+
+func testExecuteQuery(t *testing.T, testOperationContainerName string) {
 	tool, handler := ExecuteQuery(CosmosDBEmulatorClientRetriever{})
 
 	assert.Equal(t, tool.Name, EXECUTE_QUERY_TOOL_NAME)
@@ -694,7 +975,7 @@ func TestExecuteQuery(t *testing.T) {
 	//id := "user3"
 	partitionKeyValue := "user3"
 
-	tool, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{})
+	tool, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
 
 	// need to add an item to the container first
 	_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
@@ -735,15 +1016,65 @@ func TestExecuteQuery(t *testing.T) {
 			expectError: false,
 		},
 		{
-			name: "valid arguments - no partition key",
+			name: "valid arguments - cross partition",
+			arguments: map[string]interface{}{
+				"account":              dummy_account_does_not_matter,
+				"database":             testOperationDBName,
+				"container":            testOperationContainerName,
+				"query":                "SELECT * FROM c",
+				"enableCrossPartition": true,
+			},
+			expectError: false,
+		},
+		{
+			name: "partitionKey and enableCrossPartition both set",
+			arguments: map[string]interface{}{
+				"account":              dummy_account_does_not_matter,
+				"database":             testOperationDBName,
+				"container":            testOperationContainerName,
+				"query":                "SELECT * FROM c",
+				"partitionKey":         partitionKeyValue,
+				"enableCrossPartition": true,
+			},
+			expectError:    true,
+			expectedErrMsg: "partitionKey and enableCrossPartition are mutually exclusive",
+		},
+		{
+			name: "neither partitionKey nor enableCrossPartition set",
 			arguments: map[string]interface{}{
 				"account":   dummy_account_does_not_matter,
 				"database":  testOperationDBName,
 				"container": testOperationContainerName,
 				"query":     "SELECT * FROM c",
 			},
+			expectError:    true,
+			expectedErrMsg: "either partitionKey or enableCrossPartition must be provided",
+		},
+		{
+			name: "parameterized query",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"query":        "SELECT * FROM c WHERE c.id = @id",
+				"partitionKey": partitionKeyValue,
+				"parameters":   `[{"name":"@id","value":"user3"}]`,
+			},
 			expectError: false,
 		},
+		{
+			name: "malformed parameters JSON",
+			arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"query":        "SELECT * FROM c WHERE c.id = @id",
+				"partitionKey": partitionKeyValue,
+				"parameters":   `not json`,
+			},
+			expectError:    true,
+			expectedErrMsg: "error unmarshalling parameters JSON",
+		},
 		{
 			name: "empty account name",
 			arguments: map[string]interface{}{
@@ -819,12 +1150,399 @@ func TestExecuteQuery(t *testing.T) {
 			var response ExecuteQueryResponse
 			err = json.Unmarshal([]byte(textResult), &response)
 			require.NoError(t, err)
-			assert.NotEmpty(t, response.QueryResults)
-			assert.NotEmpty(t, response.QueryMetrics)
+			assert.NotEmpty(t, response.Items)
+			assert.NotEmpty(t, response.ActivityID)
 		})
 	}
 }
 
+func TestExecuteQueryPagination(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testExecuteQueryPagination(t, containerClient.ID())
+	})
+}
+
+func testExecuteQueryPagination(t *testing.T, testOperationContainerName string) {
+	_, handler := ExecuteQuery(CosmosDBEmulatorClientRetriever{})
+	_, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+
+	// The request this test guards against called for >1000 documents; that
+	// count is scaled down here to keep the emulator suite fast, but the
+	// page size is kept small relative to it so the same multi-page,
+	// disjoint-results behavior is exercised across many more than two pages.
+	const itemCount = 120
+	const pageSize = 25
+
+	for i := 0; i < itemCount; i++ {
+		id := fmt.Sprintf("pagination-item-%d", i)
+		_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
+			Params: struct {
+				Name      string                 `json:"name"`
+				Arguments map[string]interface{} `json:"arguments,omitempty"`
+				Meta      *struct {
+					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+				} `json:"_meta,omitempty"`
+			}{
+				Arguments: map[string]interface{}{
+					"account":      dummy_account_does_not_matter,
+					"database":     testOperationDBName,
+					"container":    testOperationContainerName,
+					"partitionKey": id,
+					"item":         fmt.Sprintf(`{"id": "%s", "value": "paginationTest"}`, id),
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	seenIDs := map[string]bool{}
+	continuationToken := ""
+
+	for page := 0; page < itemCount; page++ {
+		arguments := map[string]interface{}{
+			"account":              dummy_account_does_not_matter,
+			"database":             testOperationDBName,
+			"container":            testOperationContainerName,
+			"query":                "SELECT * FROM c WHERE c.value = 'paginationTest'",
+			"enableCrossPartition": true,
+			"maxItemCount":         pageSize,
+		}
+		if continuationToken != "" {
+			arguments["continuationToken"] = continuationToken
+		}
+
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: struct {
+				Name      string                 `json:"name"`
+				Arguments map[string]interface{} `json:"arguments,omitempty"`
+				Meta      *struct {
+					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+				} `json:"_meta,omitempty"`
+			}{
+				Arguments: arguments,
+			},
+		})
+		require.NoError(t, err)
+
+		var response ExecuteQueryResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextFromToolResult(t, result)), &response))
+
+		// Every id returned on this page must be new - pages must be
+		// disjoint from everything seen on earlier pages.
+		for _, rawItem := range response.Items {
+			var item map[string]interface{}
+			require.NoError(t, json.Unmarshal(rawItem, &item))
+			id := item["id"].(string)
+			require.Falsef(t, seenIDs[id], "item %q returned on more than one page", id)
+			seenIDs[id] = true
+		}
+
+		if response.ContinuationToken == "" {
+			break
+		}
+		continuationToken = response.ContinuationToken
+	}
+
+	assert.Len(t, seenIDs, itemCount)
+
+	assert.True(t, seenIDs["pagination-item-0"])
+	assert.True(t, seenIDs["pagination-item-1"])
+	assert.True(t, seenIDs["pagination-item-2"])
+}
+
+// TestExecuteQueryPartitionScopedIsCheaperThanCrossPartition guards the
+// partitionKey / enableCrossPartition split in execute_query: reading a
+// single partition directly should consume fewer RUs than fanning out
+// across every partition in the container, since the container is
+// provisioned with a real partition key path (see testPartitionKey in
+// helper_test.go) rather than a synthetic one.
+func TestExecuteQueryPartitionScopedIsCheaperThanCrossPartition(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testExecuteQueryPartitionScopedIsCheaperThanCrossPartition(t, containerClient.ID())
+	})
+}
+
+func testExecuteQueryPartitionScopedIsCheaperThanCrossPartition(t *testing.T, testOperationContainerName string) {
+	_, handler := ExecuteQuery(CosmosDBEmulatorClientRetriever{})
+	_, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+
+	for i := 0; i < 20; i++ {
+		id := fmt.Sprintf("ru-comparison-item-%d", i)
+		_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
+			Params: struct {
+				Name      string                 `json:"name"`
+				Arguments map[string]interface{} `json:"arguments,omitempty"`
+				Meta      *struct {
+					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+				} `json:"_meta,omitempty"`
+			}{
+				Arguments: map[string]interface{}{
+					"account":      dummy_account_does_not_matter,
+					"database":     testOperationDBName,
+					"container":    testOperationContainerName,
+					"partitionKey": id,
+					"item":         fmt.Sprintf(`{"id": "%s", "value": "ruComparisonTest"}`, id),
+				},
+			},
+		})
+		require.NoError(t, err)
+	}
+
+	runQuery := func(arguments map[string]interface{}) ExecuteQueryResponse {
+		result, err := handler(context.Background(), mcp.CallToolRequest{
+			Params: struct {
+				Name      string                 `json:"name"`
+				Arguments map[string]interface{} `json:"arguments,omitempty"`
+				Meta      *struct {
+					ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+				} `json:"_meta,omitempty"`
+			}{
+				Arguments: arguments,
+			},
+		})
+		require.NoError(t, err)
+
+		var response ExecuteQueryResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextFromToolResult(t, result)), &response))
+		return response
+	}
+
+	partitionScoped := runQuery(map[string]interface{}{
+		"account":      dummy_account_does_not_matter,
+		"database":     testOperationDBName,
+		"container":    testOperationContainerName,
+		"query":        "SELECT * FROM c WHERE c.id = 'ru-comparison-item-0'",
+		"partitionKey": "ru-comparison-item-0",
+	})
+	require.Len(t, partitionScoped.Items, 1)
+
+	crossPartition := runQuery(map[string]interface{}{
+		"account":              dummy_account_does_not_matter,
+		"database":             testOperationDBName,
+		"container":            testOperationContainerName,
+		"query":                "SELECT * FROM c WHERE c.value = 'ruComparisonTest'",
+		"enableCrossPartition": true,
+		"maxItemCount":         20,
+	})
+	require.Len(t, crossPartition.Items, 20)
+
+	assert.Less(t, partitionScoped.RequestCharge, crossPartition.RequestCharge)
+}
+
+func TestExecuteQueryRetriesOnThrottling(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testExecuteQueryRetriesOnThrottling(t, containerClient.ID())
+	})
+}
+
+func testExecuteQueryRetriesOnThrottling(t *testing.T, testOperationContainerName string) {
+	_, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+	id := "throttle-retry-item"
+	_, err := addItemHandler(context.Background(), mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: map[string]interface{}{
+				"account":      dummy_account_does_not_matter,
+				"database":     testOperationDBName,
+				"container":    testOperationContainerName,
+				"partitionKey": id,
+				"item":         fmt.Sprintf(`{"id": "%s", "value": "throttleRetryTest"}`, id),
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	_, handler := ExecuteQuery(throttledEmulatorClientRetriever{throttleCount: 2, retryAfterMs: "10"})
+
+	result, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: map[string]interface{}{
+				"account":              dummy_account_does_not_matter,
+				"database":             testOperationDBName,
+				"container":            testOperationContainerName,
+				"query":                "SELECT * FROM c WHERE c.value = 'throttleRetryTest'",
+				"enableCrossPartition": true,
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	var response ExecuteQueryResponse
+	require.NoError(t, json.Unmarshal([]byte(getTextFromToolResult(t, result)), &response))
+	assert.Equal(t, 2, response.RetryCount)
+	assert.GreaterOrEqual(t, response.RetryDelayMs, int64(20))
+	assert.Len(t, response.Items, 1)
+}
+
+// TestExecuteQueryGivesUpAfterMaxRetryAttempts doesn't convert to
+// testHarness.WithContainer: throttledEmulatorClientRetriever never lets the
+// query reach the emulator, so the assertion never depends on what's
+// actually in testOperationContainerName - it's still safe to run with
+// t.Parallel() alongside tests that write real data there.
+func TestExecuteQueryGivesUpAfterMaxRetryAttempts(t *testing.T) {
+	t.Parallel()
+	_, handler := ExecuteQuery(throttledEmulatorClientRetriever{throttleCount: 10, retryAfterMs: "10"})
+
+	_, err := handler(context.Background(), mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: map[string]interface{}{
+				"account":              dummy_account_does_not_matter,
+				"database":             testOperationDBName,
+				"container":            testOperationContainerName,
+				"query":                "SELECT * FROM c",
+				"enableCrossPartition": true,
+				"maxRetryAttempts":     float64(3),
+			},
+		},
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query page error")
+}
+
+func TestSubscribeChangeFeed(t *testing.T) {
+	t.Parallel()
+	testHarness.WithContainer(t, func(containerClient *azcosmos.ContainerClient) {
+		testSubscribeChangeFeed(t, containerClient.ID())
+	})
+}
+
+func testSubscribeChangeFeed(t *testing.T, testOperationContainerName string) {
+	tool, handler := SubscribeChangeFeed(CosmosDBEmulatorClientRetriever{})
+	_, addItemHandler := AddItemToContainer(CosmosDBEmulatorClientRetriever{}, NewSchemaRegistry())
+	_, resetLeaseHandler := ResetChangeFeedLease(CosmosDBEmulatorClientRetriever{})
+
+	assert.Equal(t, tool.Name, SUBSCRIBE_CHANGE_FEED_TOOL_NAME)
+	assert.NotEmpty(t, tool.Description)
+
+	leaseContainerName := testOperationContainerName + "-leases"
+
+	// start from a clean lease so this test is independent of prior runs
+	_, err := resetLeaseHandler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account":        dummy_account_does_not_matter,
+		"database":       testOperationDBName,
+		"container":      testOperationContainerName,
+		"leaseContainer": leaseContainerName,
+	}))
+	require.NoError(t, err)
+
+	id := "change-feed-item"
+	_, err = addItemHandler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account":      dummy_account_does_not_matter,
+		"database":     testOperationDBName,
+		"container":    testOperationContainerName,
+		"partitionKey": id,
+		"item":         fmt.Sprintf(`{"id": "%s", "value": "changeFeedTest"}`, id),
+	}))
+	require.NoError(t, err)
+
+	seenIDs := map[string]bool{}
+
+	for page := 0; page < 10 && !seenIDs[id]; page++ {
+		arguments := map[string]interface{}{
+			"account":        dummy_account_does_not_matter,
+			"database":       testOperationDBName,
+			"container":      testOperationContainerName,
+			"leaseContainer": leaseContainerName,
+			"startFrom":      "Beginning",
+		}
+
+		result, err := handler(context.Background(), newCallToolRequest(arguments))
+		require.NoError(t, err)
+
+		var response ChangeFeedResponse
+		require.NoError(t, json.Unmarshal([]byte(getTextFromToolResult(t, result)), &response))
+		assert.NotEmpty(t, response.LeaseDocumentID)
+
+		for _, rawChange := range response.Changes {
+			var change map[string]interface{}
+			require.NoError(t, json.Unmarshal(rawChange, &change))
+			seenIDs[change["id"].(string)] = true
+		}
+
+		if response.NewContinuation == "" {
+			break
+		}
+	}
+
+	assert.True(t, seenIDs[id])
+}
+
+// TestEmulatorHarnessWithContainerParallel demonstrates EmulatorHarness:
+// each subtest gets its own container via WithContainer, so, like the
+// handler tests above, these are safe to run with t.Parallel().
+func TestEmulatorHarnessWithContainerParallel(t *testing.T) {
+	client, err := CosmosDBEmulatorClientRetriever{}.Get(dummy_account_does_not_matter)
+	require.NoError(t, err)
+	harness := NewEmulatorHarness(client)
+
+	for i := 0; i < 3; i++ {
+		t.Run(fmt.Sprintf("subtest-%d", i), func(t *testing.T) {
+			t.Parallel()
+
+			harness.WithContainer(t, func(container *azcosmos.ContainerClient) {
+				ctx := context.Background()
+				itemID := "isolated-item"
+				_, err := container.CreateItem(ctx, azcosmos.NewPartitionKeyString(itemID), []byte(fmt.Sprintf(`{"id":"%s"}`, itemID)), nil)
+				require.NoError(t, err)
+
+				itemResponse, err := container.ReadItem(ctx, azcosmos.NewPartitionKeyString(itemID), itemID, nil)
+				require.NoError(t, err)
+				assert.NotEmpty(t, itemResponse.Value)
+			})
+		})
+	}
+}
+
+// TestContainerSnapshotRestore exercises Snapshot/Restore: writes made after
+// a snapshot is taken are undone by Restore, returning the container to
+// exactly the item set it held at snapshot time.
+func TestContainerSnapshotRestore(t *testing.T) {
+	client, err := CosmosDBEmulatorClientRetriever{}.Get(dummy_account_does_not_matter)
+	require.NoError(t, err)
+	harness := NewEmulatorHarness(client)
+
+	harness.WithContainer(t, func(container *azcosmos.ContainerClient) {
+		ctx := context.Background()
+
+		_, err := container.CreateItem(ctx, azcosmos.NewPartitionKeyString("keep-me"), []byte(`{"id":"keep-me"}`), nil)
+		require.NoError(t, err)
+
+		snapshot, err := Snapshot(ctx, container)
+		require.NoError(t, err)
+
+		_, err = container.CreateItem(ctx, azcosmos.NewPartitionKeyString("undo-me"), []byte(`{"id":"undo-me"}`), nil)
+		require.NoError(t, err)
+
+		require.NoError(t, snapshot.Restore(ctx, container))
+
+		_, err = container.ReadItem(ctx, azcosmos.NewPartitionKeyString("keep-me"), "keep-me", nil)
+		require.NoError(t, err)
+
+		_, err = container.ReadItem(ctx, azcosmos.NewPartitionKeyString("undo-me"), "undo-me", nil)
+		require.Error(t, err)
+	})
+}
+
 func TestMain(m *testing.M) {
 	// Set up the CosmosDB emulator container
 	ctx := context.Background()
@@ -849,6 +1567,8 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
+	testHarness = NewEmulatorHarness(client)
+
 	// Run the tests
 	code := m.Run()
 
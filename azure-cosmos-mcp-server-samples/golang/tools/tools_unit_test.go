@@ -0,0 +1,309 @@
+package tools
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newCallToolRequest builds an mcp.CallToolRequest carrying arguments, the
+// same way the (emulator-backed) integration tests in tools_test.go do.
+func newCallToolRequest(arguments map[string]interface{}) mcp.CallToolRequest {
+	return mcp.CallToolRequest{
+		Params: struct {
+			Name      string                 `json:"name"`
+			Arguments map[string]interface{} `json:"arguments,omitempty"`
+			Meta      *struct {
+				ProgressToken mcp.ProgressToken `json:"progressToken,omitempty"`
+			} `json:"_meta,omitempty"`
+		}{
+			Arguments: arguments,
+		},
+	}
+}
+
+// assertToolSchema checks that a tool's metadata - name, description and
+// declared input properties/required set - matches what's expected, without
+// ever invoking its handler. Catches tool metadata regressions in CI without
+// needing a Cosmos DB emulator.
+func assertToolSchema(t *testing.T, tool mcp.Tool, wantName string, wantProperties, wantRequired []string) {
+	t.Helper()
+
+	assert.Equal(t, wantName, tool.Name)
+	assert.NotEmpty(t, tool.Description)
+
+	for _, property := range wantProperties {
+		assert.Contains(t, tool.InputSchema.Properties, property)
+	}
+	assert.ElementsMatch(t, wantRequired, tool.InputSchema.Required)
+}
+
+func TestListDatabasesUnit(t *testing.T) {
+	tool, handler := ListDatabases(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, LIST_DATABASES_TOOL_NAME, []string{"account"}, []string{"account"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{"account": ""}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cosmos db account name missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{"account": "some-account"}))
+	require.Error(t, err)
+}
+
+func TestListContainersUnit(t *testing.T) {
+	tool, handler := ListContainers(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, LIST_CONTAINERS_TOOL_NAME, []string{"account", "database"}, []string{"account", "database"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{"account": "", "database": "db"}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cosmos db account name missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{"account": "acct", "database": ""}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "database name missing")
+}
+
+func TestReadContainerMetadataUnit(t *testing.T) {
+	tool, handler := ReadContainerMetadata(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, READ_CONTAINER_METADATA_TOOL_NAME,
+		[]string{"account", "database", "container"},
+		[]string{"account", "database", "container"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{"account": "acct", "database": "db", "container": ""}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "container name missing")
+}
+
+func TestCreateContainerUnit(t *testing.T) {
+	tool, handler := CreateContainer(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, CREATE_CONTAINER_TOOL_NAME,
+		[]string{"account", "database", "container", "partitionKeyPath", "throughput"},
+		[]string{"account", "database", "container"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKeyPath": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partition key path missing")
+}
+
+func TestAddItemToContainerUnit(t *testing.T) {
+	tool, handler := AddItemToContainer(FakeCosmosDBClientRetriever{}, NewSchemaRegistry())
+
+	assertToolSchema(t, tool, ADD_CONTAINER_ITEM_TOOL_NAME,
+		[]string{"account", "database", "container", "partitionKey", "item"},
+		[]string{"account", "database", "container", "partitionKey", "item"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk", "item": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item JSON missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk", "item": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling item JSON")
+}
+
+func TestReplaceItemUnit(t *testing.T) {
+	tool, handler := ReplaceItem(FakeCosmosDBClientRetriever{}, NewSchemaRegistry())
+
+	assertToolSchema(t, tool, REPLACE_ITEM_TOOL_NAME,
+		[]string{"account", "database", "container", "itemID", "partitionKey", "item"},
+		[]string{"account", "database", "container", "itemID", "partitionKey", "item"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "itemID": "", "partitionKey": "pk", "item": `{"id":"1"}`,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item ID missing")
+}
+
+func TestUpsertItemUnit(t *testing.T) {
+	tool, handler := UpsertItem(FakeCosmosDBClientRetriever{}, NewSchemaRegistry())
+
+	assertToolSchema(t, tool, UPSERT_ITEM_TOOL_NAME,
+		[]string{"account", "database", "container", "partitionKey", "item"},
+		[]string{"account", "database", "container", "partitionKey", "item"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "partitionKey": "pk", "item": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling item JSON")
+}
+
+func TestDeleteItemUnit(t *testing.T) {
+	tool, handler := DeleteItem(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, DELETE_ITEM_TOOL_NAME,
+		[]string{"account", "database", "container", "itemID", "partitionKey"},
+		[]string{"account", "database", "container", "itemID", "partitionKey"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "itemID": "", "partitionKey": "pk",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item ID missing")
+}
+
+func TestRegisterItemSchemaUnit(t *testing.T) {
+	tool, handler := RegisterItemSchema(NewSchemaRegistry())
+
+	assertToolSchema(t, tool, REGISTER_ITEM_SCHEMA_TOOL_NAME,
+		[]string{"account", "database", "container", "schema"},
+		[]string{"account", "database", "container", "schema"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "schema": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "schema JSON missing")
+
+	registry := NewSchemaRegistry()
+	_, registerTool := RegisterItemSchema(registry)
+	_, err = registerTool(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c",
+		"schema": `{"properties":{"status":"string"},"required":["status"]}`,
+	}))
+	require.NoError(t, err)
+
+	schema, ok := registry.Get("acct", "db", "c")
+	require.True(t, ok)
+	assert.Equal(t, []string{"status"}, schema.Required)
+	assert.Equal(t, "string", schema.Properties["status"])
+}
+
+func TestValidateItemAgainstSchemaUnit(t *testing.T) {
+	schema := ItemSchema{
+		Properties: map[string]string{"status": "string", "count": "number"},
+		Required:   []string{"status"},
+	}
+
+	violations := validateItemAgainstSchema(map[string]interface{}{"count": "not-a-number"}, schema)
+	assert.ElementsMatch(t, []schemaViolation{
+		{Path: "/status", ExpectedType: "string", ActualType: "missing"},
+		{Path: "/count", ExpectedType: "number", ActualType: "string"},
+	}, violations)
+
+	violations = validateItemAgainstSchema(map[string]interface{}{"status": "done", "count": float64(3)}, schema)
+	assert.Empty(t, violations)
+}
+
+func TestValidateItemIdentityUnit(t *testing.T) {
+	violations := validateItemIdentity(map[string]interface{}{"department": ""}, "/department")
+	assert.ElementsMatch(t, []schemaViolation{
+		{Path: "/id", ExpectedType: "string", ActualType: "missing"},
+		{Path: "/department", ExpectedType: "non-empty value", ActualType: "empty string"},
+	}, violations)
+
+	violations = validateItemIdentity(map[string]interface{}{"id": "item1", "department": "HR"}, "/department")
+	assert.Empty(t, violations)
+}
+
+func TestReadItemUnit(t *testing.T) {
+	tool, handler := ReadItem(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, READ_ITEM_TOOL_NAME,
+		[]string{"account", "database", "container", "itemID", "partitionKey"},
+		[]string{"account", "database", "container", "itemID", "partitionKey"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "itemID": "", "partitionKey": "pk",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "item ID missing")
+}
+
+func TestExecuteQueryUnit(t *testing.T) {
+	tool, handler := ExecuteQuery(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, EXECUTE_QUERY_TOOL_NAME,
+		[]string{"account", "database", "container", "query", "partitionKey"},
+		[]string{"account", "database", "container", "query"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "query": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "query string missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "query": "SELECT * FROM c",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "either partitionKey or enableCrossPartition must be provided")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "query": "SELECT * FROM c",
+		"partitionKey": "pk", "enableCrossPartition": true,
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "partitionKey and enableCrossPartition are mutually exclusive")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "query": "SELECT * FROM c WHERE c.id = @id",
+		"partitionKey": "pk", "parameters": "not json",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "error unmarshalling parameters JSON")
+
+	// maxRetryAttempts/maxRetryWaitMs far beyond the allowed caps must be
+	// clamped rather than handed straight to retryDelay's backoff math - they
+	// should fail at client creation like any other valid-looking call, not
+	// panic.
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "query": "SELECT * FROM c",
+		"partitionKey": "pk", "maxRetryAttempts": float64(1_000_000), "maxRetryWaitMs": float64(1_000_000_000),
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "no client configured for unit tests")
+}
+
+func TestSubscribeChangeFeedUnit(t *testing.T) {
+	tool, handler := SubscribeChangeFeed(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, SUBSCRIBE_CHANGE_FEED_TOOL_NAME,
+		[]string{"account", "database", "container", "leaseContainer", "startFrom", "timestamp", "maxItems"},
+		[]string{"account", "database", "container", "leaseContainer"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "leaseContainer": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lease container name missing")
+
+	_, err = handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "leaseContainer": "leases",
+		"startFrom": "Timestamp",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "timestamp is required when startFrom is \"Timestamp\"")
+}
+
+func TestResetChangeFeedLeaseUnit(t *testing.T) {
+	tool, handler := ResetChangeFeedLease(FakeCosmosDBClientRetriever{})
+
+	assertToolSchema(t, tool, RESET_CHANGE_FEED_LEASE_TOOL_NAME,
+		[]string{"account", "database", "container", "leaseContainer"},
+		[]string{"account", "database", "container", "leaseContainer"})
+
+	_, err := handler(context.Background(), newCallToolRequest(map[string]interface{}{
+		"account": "acct", "database": "db", "container": "c", "leaseContainer": "",
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "lease container name missing")
+}
+
+func TestChangeFeedLeaseIDUnit(t *testing.T) {
+	assert.Equal(t, "changefeed-lease-db-c", changeFeedLeaseID("db", "c"))
+}
@@ -0,0 +1,194 @@
+package tools
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore"
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/tracing"
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	azotel "github.com/Azure/azure-sdk-for-go/sdk/tracing/azotel"
+	"go.opentelemetry.io/otel"
+	otelAttribute "go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	otelMetric "go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	oteltrace "go.opentelemetry.io/otel/trace"
+)
+
+const instrumentationName = "github.com/abhirockzz/mcp_cosmosdb_go/tools"
+
+// InitOTelTracing configures the process-wide OpenTelemetry TracerProvider
+// with an OTLP/gRPC exporter pointed at OTEL_EXPORTER_OTLP_ENDPOINT, if that
+// env var is set. It returns a shutdown func to flush and close the exporter
+// on process exit, and a bool reporting whether tracing was enabled. When the
+// env var is unset, it returns a no-op shutdown func and false, leaving the
+// global otel.GetTracerProvider() as the default no-op provider.
+func InitOTelTracing(ctx context.Context) (shutdown func(context.Context) error, enabled bool, err error) {
+	endpoint := OTLPEndpointFromEnv()
+	if endpoint == "" {
+		return func(context.Context) error { return nil }, false, nil
+	}
+
+	exporter, err := otlptracegrpc.New(ctx, otlptracegrpc.WithEndpoint(endpoint), otlptracegrpc.WithInsecure())
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating OTLP trace exporter: %v", err)
+	}
+
+	res, err := resource.Merge(resource.Default(), resource.NewSchemaless(
+		semconv.ServiceName("mcp-cosmosdb-go"),
+	))
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating OTel resource: %v", err)
+	}
+
+	tracerProvider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tracerProvider)
+
+	return tracerProvider.Shutdown, true, nil
+}
+
+// NewOTelTracingProvider builds an azcore tracing.Provider backed by the
+// process-wide OpenTelemetry TracerProvider, using the azcore/OTel bridge.
+// Pass the result as PooledClientRetriever.TracingProvider so
+// Cosmos SDK spans and the spans created around each tool invocation below
+// land in the same trace. Call InitOTelTracing beforehand so the process-wide
+// TracerProvider actually exports spans, rather than discarding them.
+func NewOTelTracingProvider() tracing.Provider {
+	return azotel.NewTracingProvider(otel.GetTracerProvider(), nil)
+}
+
+// OTLPEndpointFromEnv returns the OTLP exporter endpoint configured for this
+// process, if any, so callers can decide whether to wire up tracing at all.
+func OTLPEndpointFromEnv() string {
+	return os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+}
+
+// toolMetrics holds the process-wide OpenTelemetry instruments recording
+// traffic across every tool handler: how many calls were made, how many RUs
+// they cost, and how often they were throttled. Initialized lazily (via
+// initToolMetrics) so importing this package never requires a MeterProvider
+// to be configured; with no provider set, otel.Meter returns a no-op one and
+// these instruments simply record nothing.
+var (
+	toolMetricsOnce   sync.Once
+	requestCounter    otelMetric.Int64Counter
+	requestChargeHist otelMetric.Float64Histogram
+	throttleCounter   otelMetric.Int64Counter
+)
+
+func initToolMetrics() {
+	toolMetricsOnce.Do(func() {
+		meter := otel.Meter(instrumentationName)
+		requestCounter, _ = meter.Int64Counter("cosmosdb.mcp.requests",
+			otelMetric.WithDescription("Number of Cosmos DB calls made by MCP tool handlers"))
+		requestChargeHist, _ = meter.Float64Histogram("cosmosdb.mcp.request_charge",
+			otelMetric.WithDescription("Request units (RU) consumed per Cosmos DB call"),
+			otelMetric.WithUnit("{RU}"))
+		throttleCounter, _ = meter.Int64Counter("cosmosdb.mcp.throttled_requests",
+			otelMetric.WithDescription("Number of Cosmos DB calls that received a 429 Too Many Requests response"))
+	})
+}
+
+// startToolSpan starts a span for a single MCP tool invocation with the
+// standard Cosmos DB attributes (db.system, account, database, container,
+// partition key, item id, operation), matching the attributes the Cosmos SDK
+// itself records. partitionKey and itemID may be empty for operations that
+// don't target a single item (e.g. ListDatabases). The returned time.Time is
+// the call's start time, to be passed to endToolSpan for latency.
+func startToolSpan(ctx context.Context, operation, account, database, container, partitionKey, itemID string) (context.Context, oteltrace.Span, time.Time) {
+	tracer := otel.Tracer(instrumentationName)
+	attributes := []otelAttribute.KeyValue{
+		otelAttribute.String("db.system", "cosmosdb"),
+		otelAttribute.String("db.cosmosdb.account", account),
+		otelAttribute.String("db.cosmosdb.database", database),
+		otelAttribute.String("db.cosmosdb.container", container),
+		otelAttribute.String("db.operation", operation),
+	}
+	if partitionKey != "" {
+		attributes = append(attributes, otelAttribute.String("db.cosmosdb.partition_key", partitionKey))
+	}
+	if itemID != "" {
+		attributes = append(attributes, otelAttribute.String("db.cosmosdb.item_id", itemID))
+	}
+	ctx, span := tracer.Start(ctx, operation, oteltrace.WithAttributes(attributes...))
+	return ctx, span, time.Now()
+}
+
+// endToolSpan records the outcome of a tool invocation - latency, RU charge
+// and/or status code when known, the error if one occurred - on both the
+// span and the package's request-count/RU-histogram/throttle-counter
+// metrics, then closes the span.
+func endToolSpan(ctx context.Context, span oteltrace.Span, operation string, start time.Time, requestCharge float32, statusCode int, err error) {
+	initToolMetrics()
+
+	latency := time.Since(start)
+	span.SetAttributes(otelAttribute.Int64("db.cosmosdb.latency_ms", latency.Milliseconds()))
+	if requestCharge > 0 {
+		span.SetAttributes(otelAttribute.Float64("db.cosmosdb.request_charge", float64(requestCharge)))
+	}
+	if statusCode > 0 {
+		span.SetAttributes(otelAttribute.Int("db.cosmosdb.status_code", statusCode))
+	}
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+
+	operationAttr := otelAttribute.String("db.operation", operation)
+	requestCounter.Add(ctx, 1, otelMetric.WithAttributes(operationAttr, otelAttribute.Bool("error", err != nil)))
+	if requestCharge > 0 {
+		requestChargeHist.Record(ctx, float64(requestCharge), otelMetric.WithAttributes(operationAttr))
+	}
+	if is429(err) {
+		throttleCounter.Add(ctx, 1, otelMetric.WithAttributes(operationAttr))
+	}
+}
+
+// is429 reports whether err is a Cosmos DB 429 Too Many Requests response.
+func is429(err error) bool {
+	var responseErr *azcore.ResponseError
+	if errors.As(err, &responseErr) {
+		return responseErr.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// TracingClientRetriever wraps another CosmosDBClientRetriever and records a
+// span around its Get call, so client acquisition (including cache misses
+// that build a brand new *azcosmos.Client) shows up in tracing. Get takes no
+// context, so the span is started from context.Background(): it is a root
+// span in its own trace, not a child of the tool invocation's span. This is
+// independent of
+// PooledClientRetriever.TracingProvider, which instruments the SDK's own
+// HTTP calls rather than the retriever's cache lookup.
+type TracingClientRetriever struct {
+	Inner CosmosDBClientRetriever
+}
+
+func (retriever TracingClientRetriever) Get(accountName string) (*azcosmos.Client, error) {
+	tracer := otel.Tracer(instrumentationName)
+	_, span := tracer.Start(context.Background(), "cosmosdb.client.get", oteltrace.WithAttributes(
+		otelAttribute.String("db.cosmosdb.account", accountName),
+	))
+	defer span.End()
+
+	client, err := retriever.Inner.Get(accountName)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	return client, err
+}
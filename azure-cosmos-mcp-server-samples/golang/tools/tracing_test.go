@@ -0,0 +1,23 @@
+package tools
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracingClientRetrieverUnit(t *testing.T) {
+	wantErr := errors.New("boom")
+	retriever := TracingClientRetriever{Inner: FakeCosmosDBClientRetriever{Err: wantErr}}
+
+	client, err := retriever.Get("some-account")
+	assert.Nil(t, client)
+	assert.Equal(t, wantErr, err)
+}
+
+func TestIs429Unit(t *testing.T) {
+	assert.True(t, is429(throttledResponseError("")))
+	assert.False(t, is429(errors.New("not a response error")))
+	assert.False(t, is429(nil))
+}
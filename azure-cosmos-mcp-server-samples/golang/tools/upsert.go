@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/Azure/azure-sdk-for-go/sdk/data/azcosmos"
+	"github.com/mark3labs/mcp-go/mcp"
+	"github.com/mark3labs/mcp-go/server"
+)
+
+const UPSERT_ITEM_TOOL_NAME = "upsert_item"
+
+// UpsertItem creates an item, or replaces it in-place if an item with the
+// same id and partition key already exists. Unlike BulkUpsertItems, this
+// operates on a single item and is validated the same way AddItemToContainer
+// and ReplaceItem are.
+func UpsertItem(clientRetriever CosmosDBClientRetriever, schemaRegistry *SchemaRegistry) (mcp.Tool, server.ToolHandlerFunc) {
+	return upsertItem(), func(ctx context.Context, request mcp.CallToolRequest) (result *mcp.CallToolResult, err error) {
+
+		account, ok := request.Params.Arguments["account"].(string)
+		if !ok || account == "" {
+			return nil, errors.New("cosmos db account name missing")
+		}
+		database, ok := request.Params.Arguments["database"].(string)
+		if !ok || database == "" {
+			return nil, errors.New("database name missing")
+		}
+		container, ok := request.Params.Arguments["container"].(string)
+		if !ok || container == "" {
+			return nil, errors.New("container name missing")
+		}
+		partitionKeyValue, ok := request.Params.Arguments["partitionKey"].(string)
+		if !ok || partitionKeyValue == "" {
+			return nil, errors.New("value for partition key missing")
+		}
+		itemJSON, ok := request.Params.Arguments["item"].(string)
+		if !ok || itemJSON == "" {
+			return nil, errors.New("item JSON missing")
+		}
+
+		var item map[string]interface{}
+		if err := json.Unmarshal([]byte(itemJSON), &item); err != nil {
+			return nil, fmt.Errorf("error unmarshalling item JSON: %v", err)
+		}
+
+		var requestCharge float32
+		ctx, span, start := startToolSpan(ctx, UPSERT_ITEM_TOOL_NAME, account, database, container, partitionKeyValue, "")
+		defer func() { endToolSpan(ctx, span, UPSERT_ITEM_TOOL_NAME, start, requestCharge, 0, err) }()
+
+		client, err := clientRetriever.Get(account)
+		if err != nil {
+			fmt.Printf("Error creating Cosmos client: %v\n", err)
+			return nil, err
+		}
+
+		databaseClient, err := client.NewDatabase(database)
+		if err != nil {
+			return nil, fmt.Errorf("error creating database client: %v", err)
+		}
+
+		containerClient, err := databaseClient.NewContainer(container)
+		if err != nil {
+			return nil, fmt.Errorf("error creating container client: %v", err)
+		}
+
+		partitionKeyPath, err := fetchPartitionKeyPath(ctx, containerClient)
+		if err != nil {
+			return nil, fmt.Errorf("error reading container metadata: %v", err)
+		}
+
+		violations := validateItemIdentity(item, partitionKeyPath)
+		if schema, ok := schemaRegistry.Get(account, database, container); ok {
+			violations = append(violations, validateItemAgainstSchema(item, schema)...)
+		}
+		if len(violations) > 0 {
+			jsonResult, err := json.Marshal(ItemValidationResponse{Valid: false, Violations: violations})
+			if err != nil {
+				return nil, fmt.Errorf("error marshalling validation result to JSON: %v", err)
+			}
+			return mcp.NewToolResultText(string(jsonResult)), nil
+		}
+
+		partitionKey := azcosmos.NewPartitionKeyString(partitionKeyValue)
+
+		var itemResponse azcosmos.ItemResponse
+		attempts, _, retryErr := retryOnThrottle(ctx, RetryPolicyFromEnv(), func() error {
+			var upsertErr error
+			itemResponse, upsertErr = containerClient.UpsertItem(ctx, partitionKey, []byte(itemJSON), nil)
+			return upsertErr
+		})
+		if retryErr != nil {
+			return nil, wrapRetryExhausted("error upserting item", attempts+1, retryErr)
+		}
+		requestCharge = itemResponse.RequestCharge
+
+		return mcp.NewToolResultText(string(itemResponse.Value)), nil
+	}
+}
+
+func upsertItem() mcp.Tool {
+	return mcp.NewTool(UPSERT_ITEM_TOOL_NAME,
+		mcp.WithString("account",
+			mcp.Required(),
+			mcp.Description(ACCOUNT_PARAMETER_DESCRIPTION),
+		),
+		mcp.WithString("database",
+			mcp.Required(),
+			mcp.Description("Name of the database"),
+		),
+		mcp.WithString("container",
+			mcp.Required(),
+			mcp.Description("Name of the container to upsert the item into"),
+		),
+		mcp.WithString("partitionKey",
+			mcp.Required(),
+			mcp.Description("Partition key of the item"),
+		),
+		mcp.WithString("item",
+			mcp.Required(),
+			mcp.Description("The full JSON representation of the item to upsert. id field is mandatory"),
+		),
+		mcp.WithDescription("Create an item, or replace it in-place if an item with the same id and partition key already exists."),
+	)
+}